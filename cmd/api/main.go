@@ -1,252 +1,280 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-web-api-study/internal/bench"
+	"go-web-api-study/internal/blanks"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/compare"
+	"go-web-api-study/internal/editor"
+	"go-web-api-study/internal/grading"
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/middleware"
+	"go-web-api-study/internal/progress"
+	"go-web-api-study/internal/quiz"
+	"go-web-api-study/internal/review"
+	"go-web-api-study/internal/router"
+	"go-web-api-study/internal/runner"
+	"go-web-api-study/internal/scaffold"
+	"go-web-api-study/internal/search"
+	"go-web-api-study/internal/service"
+	"go-web-api-study/internal/snippet"
+	"go-web-api-study/internal/symbols"
+	"go-web-api-study/internal/watch"
+	"go-web-api-study/internal/web"
 )
 
-// serveSourceCode 返回一个处理器函数，用于显示源代码
-func serveSourceCode(filePath string) http.HandlerFunc {
+// serveCatalogFile 按请求路径在目录中查找文件并显示，找不到时返回 404
+func serveCatalogFile(cat *catalog.Catalog, pages *web.Pages) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			http.Error(w, "文件不存在: "+filePath, http.StatusNotFound)
+		e, ok := cat.Lookup(strings.TrimSuffix(r.URL.Path, "/"))
+		if !ok {
+			http.NotFound(w, r)
 			return
 		}
+		pages.Source(e)(w, r)
+	}
+}
+
+// compareRequest POST /api/v1/compare 的请求
+type compareRequest struct {
+	Day int `json:"day" validate:"min=1"`
+}
 
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		fmt.Fprintf(w, `
-		<html>
-		<head>
-			<title>%s - 源代码查看</title>
-			<style>
-				body { font-family: 'Courier New', monospace; margin: 20px; }
-				.header { background: #f0f0f0; padding: 10px; margin-bottom: 20px; }
-				.code { background: #f8f8f8; padding: 15px; border: 1px solid #ddd; white-space: pre-wrap; }
-				.nav { margin-bottom: 20px; }
-				.nav a { margin-right: 10px; color: #0066cc; text-decoration: none; }
-				.run-btn { background: #4CAF50; color: white; padding: 8px 16px; border: none; cursor: pointer; margin: 10px 0; }
-			</style>
-		</head>
-		<body>
-			<div class="nav">
-				<a href="/">🏠 首页</a>
-				<a href="/exercises">📚 练习列表</a>
-				<a href="/gobase">🔧 基础模块</a>
-			</div>
-			<div class="header">
-				<h2>📄 %s</h2>
-				<p>💡 命令行运行: <code>go run %s</code></p>
-			</div>
-			<div class="code">%s</div>
-		</body>
-		</html>
-		`, filepath.Base(filePath), filepath.Base(filePath), filePath, string(content))
+// catalogHandler 目录索引接口：GET 返回全部条目，POST 先重新扫描磁盘
+func catalogHandler(cat *catalog.Catalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := cat.Reload(); err != nil {
+				handler.ErrorResponseFrom(w, r, http.StatusInternalServerError, err)
+				return
+			}
+		}
+		handler.SuccessResponse(w, map[string]interface{}{
+			"loaded_at": cat.LoadedAt(),
+			"entries":   cat.Entries(""),
+		})
 	}
 }
 
 func main() {
+	// 启动时扫描学习资料目录
+	cat, err := catalog.Load(".")
+	if err != nil {
+		log.Fatalf("加载学习目录失败: %v", err)
+	}
+	// Go 源码符号索引，用于大纲和跳转到定义
+	idx := symbols.NewIndex(cat)
+	// 页面模板和嵌入的静态资源（CSS、图标、docs 中的路线图）
+	renderer, err := web.NewRenderer()
+	if err != nil {
+		log.Fatalf("加载页面模板失败: %v", err)
+	}
+	pages := web.NewPages(cat, idx, renderer)
+
+	// 路由：Go 1.22 ServeMux 模式，路径匹配但方法不对时返回 405 和 Allow 头
+	app := router.New()
+	app.NotFound = handler.NotFound
+	app.MethodNotAllowed = handler.MethodNotAllowed
+	api := app.Group("/api/v1")
+
+	app.Handle("GET "+web.StaticPrefix, renderer.Assets())
+
 	// 健康检查端点
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	app.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		fmt.Fprintf(w, `{"status": "ok", "message": "Go Web API Study Server is running"}`)
 	})
 
 	// 欢迎页面
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/" {
-			http.NotFound(w, r)
-			return
-		}
+	app.HandleFunc("GET /{$}", pages.Home)
 
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		fmt.Fprintf(w, `
-		<html>
-		<head>
-			<title>Go Web API 学习项目</title>
-			<style>
-				body { font-family: Arial, sans-serif; margin: 40px; background: #f5f5f5; }
-				.container { max-width: 800px; margin: 0 auto; background: white; padding: 30px; border-radius: 10px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
-				h1 { color: #333; text-align: center; }
-				.section { margin: 20px 0; padding: 15px; background: #f9f9f9; border-radius: 5px; }
-				.link-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin: 20px 0; }
-				.link-card { background: #007acc; color: white; padding: 15px; text-align: center; border-radius: 5px; text-decoration: none; }
-				.link-card:hover { background: #005a9e; }
-			</style>
-		</head>
-		<body>
-			<div class="container">
-				<h1>🚀 Go Web API 学习项目</h1>
-				<div class="section">
-					<h2>📚 学习资源</h2>
-					<div class="link-grid">
-						<a href="/exercises" class="link-card">📝 每日练习</a>
-						<a href="/gobase" class="link-card">🔧 Go基础模块</a>
-						<a href="/api/hello" class="link-card">🌐 API示例</a>
-						<a href="/health" class="link-card">💚 健康检查</a>
-					</div>
-				</div>
-				<div class="section">
-					<h3>🎯 学习目标</h3>
-					<ul>
-						<li>掌握Go语言基础语法</li>
-						<li>学习HTTP服务开发</li>
-						<li>构建RESTful API</li>
-						<li>数据库操作与ORM</li>
-						<li>中间件和路由</li>
-					</ul>
-				</div>
-			</div>
-		</body>
-		</html>
-		`)
-	})
+	// 根目录下的 Markdown 文档，例如 /README.md
+	app.HandleFunc("GET /{file}", serveCatalogFile(cat, pages))
 
 	// API示例端点
-	http.HandleFunc("/api/hello", func(w http.ResponseWriter, r *http.Request) {
+	app.HandleFunc("GET /api/hello", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		fmt.Fprintf(w, `{"message": "Hello from Go Web API!", "timestamp": "%s"}`, "2024-01-01T00:00:00Z")
 	})
 
-	// 练习目录页面
-	http.HandleFunc("/exercises", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		fmt.Fprintf(w, `
-		<html>
-		<head>
-			<title>每日练习 - Go学习</title>
-			<style>
-				body { font-family: Arial, sans-serif; margin: 40px; }
-				.nav { margin-bottom: 20px; }
-				.nav a { margin-right: 10px; color: #0066cc; text-decoration: none; }
-				.exercise-list { display: grid; grid-template-columns: repeat(auto-fit, minmax(250px, 1fr)); gap: 15px; }
-				.exercise-card { background: #f0f8ff; padding: 15px; border-radius: 5px; border: 1px solid #ddd; }
-				.exercise-card h3 { margin-top: 0; color: #333; }
-			</style>
-		</head>
-		<body>
-			<div class="nav">
-				<a href="/">🏠 首页</a>
-				<a href="/gobase">🔧 基础模块</a>
-			</div>
-			<h1>📝 每日练习</h1>
-			<div class="exercise-list">
-				<div class="exercise-card">
-					<h3>Day 01 - Hello World</h3>
-					<p>基础输出和变量练习</p>
-					<a href="/exercises/day01">查看源代码</a>
-				</div>
-				<div class="exercise-card">
-					<h3>Day 02 - 变量练习</h3>
-					<p>变量声明和类型转换</p>
-					<a href="/exercises/day02">查看源代码</a>
-				</div>
-			</div>
-		</body>
-		</html>
-		`)
+	// 用户接口：注册 POST /api/v1/users，查询/更新/删除 /api/v1/users/{id}，登录 POST /api/v1/auth/login（内存存储，请求写入日志）
+	handler.NewUserHandler(service.NewUserService()).Routes(api.Group("", middleware.Logger))
+
+	// 练习目录页面（由 catalog 扫描 exercises/dayNN 自动生成）
+	app.HandleFunc("GET /exercises", pages.Exercises)
+
+	// Go基础模块页面（由 gobase/manifest.json 模块清单生成）
+	app.HandleFunc("GET /gobase", pages.Gobase)
+
+	// 练习文件源代码查看：/exercises/dayNN/<文件名>、/exercises/README.md，/exercises/dayNN 跳转到当天第一个文件
+	app.HandleFunc("GET /exercises/", func(w http.ResponseWriter, r *http.Request) {
+		if e, ok := cat.Lookup(strings.TrimSuffix(r.URL.Path, "/")); ok {
+			pages.Source(e)(w, r)
+			return
+		}
+		day := strings.Trim(strings.TrimPrefix(r.URL.Path, "/exercises/"), "/")
+		if files := cat.Day(day); len(files) > 0 {
+			http.Redirect(w, r, files[0].Route, http.StatusFound)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	// Go基础模块源代码查看：/gobase/<文件名去掉 .go>，以及 /gobase/README.md
+	app.HandleFunc("GET /gobase/", serveCatalogFile(cat, pages))
+
+	// Markdown 文档查看：/docs/learning_plan.md
+	app.HandleFunc("GET /docs/", serveCatalogFile(cat, pages))
+
+	// 代码片段分享：POST /api/v1/snippets 保存，/s/{id} 查看（不在服务器上运行），内容保存在 .study/snippets/
+	snippets := snippet.New(snippet.Config{Root: cat.Root()})
+	app.HandleFunc("GET "+snippet.Prefix+"{id}", snippets.PageHandler(renderer))
+	app.HandleFunc("GET "+snippet.Prefix+"{id}/raw", snippets.RawHandler())
+	api.HandleFunc("GET /snippets", snippets.APIHandler())
+	api.HandleFunc("POST /snippets", snippets.APIHandler())
+
+	// 在子进程中运行 gobase 模块或练习（以服务器权限运行，不是沙箱），输出以 SSE 流式返回
+	run := runner.New(runner.DefaultConfig())
+	api.HandleFunc("POST /run", run.Handler(func(p string) (string, bool) {
+		e, ok := cat.LookupPath(p)
+		if !ok || (e.Kind != catalog.KindGobase && e.Kind != catalog.KindExercise) {
+			return "", false
+		}
+		return filepath.Join(cat.Root(), e.Path), true
+	}))
+
+	// 练习输出与 gobase 参考模块对比：页面 /compare/dayNN，接口 POST /api/v1/compare {"day": 3}
+	app.HandleFunc("GET /compare/{day}", func(w http.ResponseWriter, r *http.Request) {
+		day, err := strconv.Atoi(strings.TrimPrefix(router.Param(r, "day"), "day"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		report, err := compare.Run(r.Context(), run, cat, day)
+		if err != nil {
+			// 练习或参考模块不存在为 404，运行队列已满为 429，其他为 500
+			handler.WritePageError(w, r, err)
+			return
+		}
+		pages.Compare(w, r, report)
 	})
+	api.HandleFunc("POST /compare", handler.JSON(func(ctx context.Context, req compareRequest) (*compare.Report, error) {
+		return compare.Run(ctx, run, cat, req.Day)
+	}))
+
+	// 要点测验：题目来自 gobase/quiz.json，对应 gobase 文件末尾的学习要点总结；结果保存在 .study/quiz/
+	quizzes, quizResults := quiz.NewService(cat, idx), quiz.NewStore(cat.Root())
+	app.HandleFunc("GET /quiz", quizzes.IndexHandler(renderer))
+	app.HandleFunc("GET /quiz/", quizzes.PageHandler(renderer))
+	api.HandleFunc("GET /quiz", quizzes.APIHandler(quizResults))
+	api.HandleFunc("POST /quiz", quizzes.APIHandler(quizResults))
+	api.HandleFunc("GET /quiz/results", quizResults.ResultsHandler())
+
+	// 按 exercises/specs/dayNN.json 为练习评分：页面 /grade/dayNN，接口 /api/v1/grade?day=3
+	grader := grading.NewGrader(cat, run)
+	app.HandleFunc("GET /grade/{day}", grader.PageHandler(renderer))
+	api.HandleFunc("GET /grade", grader.APIHandler())
+	api.HandleFunc("POST /grade", grader.APIHandler())
+
+	// 基准测试：发现学习文件中的 Benchmark 函数（包括字符串中的示例代码），页面 /bench，接口 /api/v1/bench；历史保存在 .study/bench.json
+	benchmarks := bench.New(cat, runner.New(bench.RunnerConfig()))
+	app.HandleFunc("GET /bench", benchmarks.PageHandler(renderer))
+	api.HandleFunc("GET /bench", benchmarks.APIHandler())
+	api.HandleFunc("POST /bench", benchmarks.APIHandler())
 
-	// Go基础模块页面
-	http.HandleFunc("/gobase", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		fmt.Fprintf(w, `
-		<html>
-		<head>
-			<title>Go基础学习模块</title>
-			<style>
-				body { font-family: Arial, sans-serif; margin: 40px; }
-				.nav { margin-bottom: 20px; }
-				.nav a { margin-right: 10px; color: #0066cc; text-decoration: none; }
-				.module-list { display: grid; grid-template-columns: repeat(auto-fit, minmax(300px, 1fr)); gap: 15px; }
-				.module-card { background: #fff8dc; padding: 15px; border-radius: 5px; border: 1px solid #ddd; }
-				.module-card h3 { margin-top: 0; color: #333; }
-				.advanced { background: #f0f8ff; }
-				.http { background: #f5f5dc; }
-				.database { background: #f0fff0; }
-			</style>
-		</head>
-		<body>
-			<div class="nav">
-				<a href="/">🏠 首页</a>
-				<a href="/exercises">📝 练习</a>
-			</div>
-			<h1>🔧 Go基础学习模块</h1>
-			<div class="module-list">
-				<div class="module-card">
-					<h3>01 - 变量和类型</h3>
-					<p>变量声明、基本类型、零值、类型转换</p>
-					<a href="/gobase/01">查看源代码</a>
-				</div>
-				<div class="module-card">
-					<h3>02 - 切片与映射 CRUD</h3>
-					<p>切片/映射的创建、读取、更新、删除及清空技巧</p>
-					<a href="/gobase/02">查看源代码</a>
-				</div>
-				<div class="module-card">
-					<h3>03 - 函数</h3>
-					<p>函数定义、参数、返回值、闭包、defer</p>
-					<a href="/gobase/03">查看源代码</a>
-				</div>
-				<div class="module-card">
-					<h3>04 - 结构体和接口</h3>
-					<p>结构体、方法、接口、类型断言</p>
-					<a href="/gobase/04">查看源代码</a>
-				</div>
-				<div class="module-card">
-					<h3>05 - 并发编程</h3>
-					<p>goroutine、channel、select、同步</p>
-					<a href="/gobase/05">查看源代码</a>
-				</div>
-				<div class="module-card http">
-					<h3>06 - HTTP基础</h3>
-					<p>HTTP服务器、处理器、中间件、客户端</p>
-					<a href="/gobase/06">查看源代码</a>
-				</div>
-				<div class="module-card http">
-					<h3>07 - API开发</h3>
-					<p>RESTful API、CRUD操作、JSON处理、错误处理</p>
-					<a href="/gobase/07">查看源代码</a>
-				</div>
-				<div class="module-card database">
-					<h3>08 - 数据库操作</h3>
-					<p>SQL操作、连接池、事务、ORM基础</p>
-					<a href="/gobase/08">查看源代码</a>
-				</div>
-				<div class="module-card advanced">
-					<h3>09 - 高级特性</h3>
-					<p>并发模式、反射、泛型、性能优化、设计模式</p>
-					<a href="/gobase/09">查看源代码</a>
-				</div>
-			</div>
-		</body>
-		</html>
-		`)
+	// 学习进度：面板 /progress，接口 /api/v1/progress
+	tracker := progress.NewTracker(cat, run)
+	app.HandleFunc("GET /progress", tracker.PageHandler(renderer))
+	api.HandleFunc("GET /progress", tracker.APIHandler())
+	api.HandleFunc("POST /progress", tracker.APIHandler())
+
+	// 间隔重复复习：已完成的练习和模块按 SM-2 安排复习，页面 /review，接口 /api/v1/review；状态保存在 .study/review.json
+	scheduler := review.New(cat, tracker)
+	app.HandleFunc("GET /review", scheduler.PageHandler(renderer))
+	api.HandleFunc("GET /review", scheduler.APIHandler())
+	api.HandleFunc("POST /review", scheduler.APIHandler())
+
+	// 符号定义：/symbols?name=Stack 跳转或列出候选，接口 /api/v1/symbols?name=|path=
+	app.HandleFunc("GET /symbols", pages.Symbols)
+	api.HandleFunc("GET /symbols", idx.APIHandler())
+
+	// 全文和符号搜索：页面 /search?q=，接口 /api/v1/search?q=&limit=
+	finder := search.NewIndex(cat, idx)
+	app.HandleFunc("GET /search", finder.PageHandler(renderer))
+	api.HandleFunc("GET /search", finder.APIHandler())
+
+	// 按练习计划创建下一天的练习：POST /api/v1/exercises {"day": 5}
+	api.HandleFunc("POST /exercises", scaffold.Handler(cat))
+
+	// 填空练习：GET /api/v1/blanks?module= 列出函数，POST 生成挖空函数体的练习文件
+	api.HandleFunc("GET /blanks", blanks.Handler(cat))
+	api.HandleFunc("POST /blanks", blanks.Handler(cat))
+
+	// 练习编辑器：只允许编辑 exercises/ 下的 .go 文件，页面 /edit?path=，接口 /api/v1/editor
+	edit := editor.New(cat.Root())
+	app.HandleFunc("GET /edit", edit.PageHandler(renderer))
+	api.HandleFunc("GET /editor", edit.APIHandler())
+	api.HandleFunc("POST /editor", edit.APIHandler())
+
+	// 轮询 exercises/ 和 gobase/ 的文件变化，通过 SSE 推送给打开的源码页面；新增或删除文件时重新扫描目录
+	watcher := watch.New(cat.Root(), time.Second, "exercises", "gobase")
+	go watcher.Run(context.Background())
+	go func() {
+		changes, _ := watcher.Subscribe()
+		for c := range changes {
+			if c.Op == watch.OpModify {
+				continue
+			}
+			if err := cat.Reload(); err != nil {
+				log.Printf("重新扫描学习目录失败: %v", err)
+			}
+		}
+	}()
+	api.HandleFunc("GET /watch", watcher.Handler())
+
+	// 目录索引 API，POST 时重新扫描磁盘
+	api.HandleFunc("GET /catalog", catalogHandler(cat))
+	api.HandleFunc("POST /catalog", catalogHandler(cat))
+
+	// 已注册的路由列表，用于调试
+	api.HandleFunc("GET /routes", func(w http.ResponseWriter, r *http.Request) {
+		handler.SuccessResponse(w, app.Routes())
 	})
 
-	// 练习文件源代码查看
-	http.HandleFunc("/exercises/day01", serveSourceCode("exercises/day01/hello_world.go"))
-	http.HandleFunc("/exercises/day02", serveSourceCode("exercises/day02/variables_practice.go"))
-
-	// Go基础模块源代码查看
-	http.HandleFunc("/gobase/01", serveSourceCode("gobase/01_variables_and_types.go"))
-	http.HandleFunc("/gobase/02", serveSourceCode("gobase/02_slices_maps.go"))
-	http.HandleFunc("/gobase/03", serveSourceCode("gobase/02_functions.go"))
-	http.HandleFunc("/gobase/04", serveSourceCode("gobase/03_structs_and_interfaces.go"))
-	http.HandleFunc("/gobase/05", serveSourceCode("gobase/04_concurrency.go"))
-	http.HandleFunc("/gobase/06", serveSourceCode("gobase/05_http_basics.go"))
-	http.HandleFunc("/gobase/07", serveSourceCode("gobase/06_api_development.go"))
-	http.HandleFunc("/gobase/08", serveSourceCode("gobase/07_database_basics.go"))
-	http.HandleFunc("/gobase/09", serveSourceCode("gobase/08_advanced_features.go"))
+	// 默认只监听本机：编辑和运行接口可以写入并执行 exercises/ 下的代码，没有身份验证。
+	// 确实需要从其他机器访问时用 API_ADDR 指定监听地址，例如 API_ADDR=:8080
+	addr := "127.0.0.1:8080"
+	if s := os.Getenv("API_ADDR"); s != "" {
+		addr = s
+	}
 
 	fmt.Println("🚀 Go Web API 学习服务器启动成功!")
+	fmt.Println("🔒 监听地址: " + addr)
 	fmt.Println("📱 访问地址: http://localhost:8080")
 	fmt.Println("📚 练习目录: http://localhost:8080/exercises")
 	fmt.Println("🔧 基础模块: http://localhost:8080/gobase")
 	fmt.Println("💚 健康检查: http://localhost:8080/health")
 
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	// 错误响应默认使用统一响应结构，API_ERROR_FORMAT=problem 时改为 RFC 9457 application/problem+json；
+	// 请求的 Accept 头包含 application/problem+json 时总是返回问题详情
+	if s := os.Getenv("API_ERROR_FORMAT"); s != "" {
+		format, ok := handler.ParseErrorFormat(s)
+		if !ok {
+			log.Fatalf("API_ERROR_FORMAT 只能是 envelope 或 problem: %q", s)
+		}
+		handler.DefaultErrorFormat = format
+	}
+
+	// 所有页面和接口按 ?lang=、lang Cookie 或 Accept-Language 选择中文或英文
+	log.Fatal(http.ListenAndServe(addr, middleware.RequestID(middleware.Locale(app))))
 }
@@ -0,0 +1,45 @@
+// sitegen 把学习目录导出为静态站点：首页、练习和模块列表、每个文件的源码页面和 Markdown 文档，
+// 使用与学习服务器相同的渲染代码，链接为相对路径，可以直接从磁盘打开或部署到任意静态托管。
+//
+//	go run ./cmd/sitegen -out site -lang zh
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/symbols"
+	"go-web-api-study/internal/web"
+)
+
+func main() {
+	root := flag.String("root", ".", "仓库根目录")
+	out := flag.String("out", "site", "输出目录")
+	lang := flag.String("lang", string(i18n.Default), "界面语言: zh 或 en")
+	flag.Parse()
+
+	locale, ok := i18n.Parse(*lang)
+	if !ok {
+		log.Fatalf("不支持的语言: %s", *lang)
+	}
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		log.Fatalf("加载学习目录失败: %v", err)
+	}
+	renderer, err := web.NewRenderer()
+	if err != nil {
+		log.Fatalf("加载页面模板失败: %v", err)
+	}
+	renderer.Static = true
+
+	pages := web.NewPages(cat, symbols.NewIndex(cat), renderer)
+	n, err := pages.Export(*out, locale)
+	if err != nil {
+		log.Fatalf("导出静态站点失败: %v", err)
+	}
+	fmt.Printf("✅ 已导出 %d 个页面到 %s，打开 %s 查看\n", n, *out, filepath.Join(*out, "index.html"))
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-web-api-study/internal/bench"
+	"go-web-api-study/internal/blanks"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/compare"
+	"go-web-api-study/internal/grading"
+	"go-web-api-study/internal/runner"
+	"go-web-api-study/internal/scaffold"
+)
+
+// usage 命令行帮助
+const usage = `study - Go Web API 学习项目命令行工具
+
+用法:
+  go run ./cmd/study <命令> [参数]
+
+命令:
+  compare [-root 目录] <dayNN>   运行练习和对应的 gobase 模块，对比输出
+  new-day [-root 目录] [-name 文件名] [-date 2025-01-02] [dayNN]
+                                按练习计划创建 exercises/dayNN/，默认为已有练习的下一天
+  blanks [-root 目录] [-level easy|medium|hard] [-funcs a,b] [-name 文件名] [-list] <gobase文件> [dayNN]
+                                把 gobase 模块中的函数体挖空，生成填空练习写入 exercises/dayNN/
+  grade [-root 目录] <dayNN>     按 exercises/specs/dayNN.json 检查函数签名、运行用例和输出并评分
+  bench [-root 目录] [-list] [文件或ID]
+                                运行学习文件中的 Benchmark 函数，与上一次结果比较，有回退时退出码为 1
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "compare":
+		err = runCompare(os.Args[2:])
+	case "new-day":
+		err = runNewDay(os.Args[2:])
+	case "blanks":
+		err = runBlanks(os.Args[2:])
+	case "grade":
+		err = runGrade(os.Args[2:])
+	case "bench":
+		err = runBench(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+// parseDay 解析 day03、03、3 形式的天数
+func parseDay(s string) (int, error) {
+	day, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "day"))
+	if err != nil || day <= 0 {
+		return 0, fmt.Errorf("无效的天数: %s", s)
+	}
+	return day, nil
+}
+
+// runCompare 实现 compare 子命令
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	root := fs.String("root", ".", "仓库根目录")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("用法: study compare [-root 目录] <dayNN>")
+	}
+	day, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	report, err := compare.Run(context.Background(), runner.New(runner.DefaultConfig()), cat, day)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📅 Day %02d · %s\n", report.Day, report.Topic)
+	fmt.Printf("📝 练习: %s (阶段 %s, 退出码 %d)\n", report.Exercise.Path, report.Exercise.Result.Stage, report.Exercise.Result.ExitCode)
+	fmt.Printf("🔧 参考: %s (阶段 %s, 退出码 %d)\n\n", report.Reference.Path, report.Reference.Result.Stage, report.Reference.Result.ExitCode)
+	if report.Exercise.Stderr != "" {
+		fmt.Printf("练习的错误输出:\n%s\n", report.Exercise.Stderr)
+	}
+	for _, line := range report.Diff {
+		prefix := " "
+		switch line.Op {
+		case compare.OpDelete:
+			prefix = "-"
+		case compare.OpInsert:
+			prefix = "+"
+		}
+		fmt.Printf("%s %s\n", prefix, line.Text)
+	}
+	fmt.Printf("\n结论: %s (相似度 %.0f%%) %s\n", report.Verdict, report.Similarity*100, report.Message)
+
+	if report.Verdict != compare.VerdictPass {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runNewDay 实现 new-day 子命令
+func runNewDay(args []string) error {
+	fs := flag.NewFlagSet("new-day", flag.ExitOnError)
+	root := fs.String("root", ".", "仓库根目录")
+	name := fs.String("name", "", "文件名（不含 .go），默认由参考模块推导")
+	date := fs.String("date", "", "文件头中的日期，格式 2025-01-02，默认今天")
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		return fmt.Errorf("用法: study new-day [-root 目录] [-name 文件名] [-date 2025-01-02] [dayNN]")
+	}
+
+	opts := scaffold.Options{Name: *name}
+	if fs.NArg() == 1 {
+		day, err := parseDay(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		opts.Day = day
+	}
+	if *date != "" {
+		t, err := time.Parse("2006-01-02", *date)
+		if err != nil {
+			return fmt.Errorf("无效的日期: %s", *date)
+		}
+		opts.Date = t
+	}
+
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	result, err := scaffold.Create(cat, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已创建 Day %02d · %s\n", result.Day, result.Topic)
+	fmt.Printf("📝 文件: %s\n", result.Path)
+	if result.Module != "" {
+		fmt.Printf("🔧 参考: gobase/%s\n", result.Module)
+	}
+	fmt.Printf("▶️  运行: go run %s\n", result.Path)
+	return nil
+}
+
+// runBlanks 实现 blanks 子命令
+func runBlanks(args []string) error {
+	fs := flag.NewFlagSet("blanks", flag.ExitOnError)
+	root := fs.String("root", ".", "仓库根目录")
+	level := fs.String("level", string(blanks.LevelMedium), "难度: easy、medium、hard")
+	funcs := fs.String("funcs", "", "逗号分隔的函数名，指定后忽略 -level")
+	name := fs.String("name", "", "文件名（不含 .go），默认为 <模块名>_blanks")
+	list := fs.Bool("list", false, "只列出可以挖空的函数")
+	fs.Parse(args)
+
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	if *list && fs.NArg() == 1 {
+		candidates, err := blanks.Candidates(cat, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		for _, f := range candidates {
+			fmt.Printf("%-40s 第 %d 行，%d 行\n", f.Name, f.Line, f.Lines)
+		}
+		return nil
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("用法: study blanks [-level easy|medium|hard] [-funcs a,b] [-name 文件名] <gobase文件> <dayNN>")
+	}
+	day, err := parseDay(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	opts := blanks.Options{Module: fs.Arg(0), Day: day, Level: blanks.Level(*level), Name: *name}
+	if *funcs != "" {
+		opts.Funcs = strings.Split(*funcs, ",")
+	}
+	result, err := blanks.Generate(cat, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已生成 %s\n", result.Path)
+	fmt.Printf("✂️  挖空 %d 个函数: %s\n", len(result.Stripped), strings.Join(result.Stripped, ", "))
+	fmt.Printf("📌 保留 %d 个函数\n", len(result.Kept))
+	fmt.Printf("▶️  运行: go run %s\n", result.Path)
+	return nil
+}
+
+// runGrade 实现 grade 子命令
+func runGrade(args []string) error {
+	fs := flag.NewFlagSet("grade", flag.ExitOnError)
+	root := fs.String("root", ".", "仓库根目录")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("用法: study grade [-root 目录] <dayNN>")
+	}
+	day, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	report, err := grading.NewGrader(cat, runner.New(runner.DefaultConfig())).Grade(context.Background(), day)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🧪 Day %02d · %s\n", report.Day, report.Topic)
+	fmt.Printf("📝 练习: %s\n\n", report.Path)
+	if report.CompileError != "" {
+		fmt.Printf("编译错误:\n%s\n", report.CompileError)
+	}
+	for _, c := range report.Checks {
+		mark := "✅"
+		if !c.Passed {
+			mark = "❌"
+		}
+		fmt.Printf("%s [%s] %s\n", mark, c.Kind, c.Name)
+		if !c.Passed {
+			fmt.Printf("     期望: %s\n     实际: %s\n", c.Expected, c.Actual)
+			if c.Message != "" {
+				fmt.Printf("     说明: %s\n", c.Message)
+			}
+		}
+	}
+	fmt.Printf("\n得分: %.1f（通过 %d / %d）\n", report.Score, report.Passed, report.Total)
+
+	if report.Passed != report.Total {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runBench 实现 bench 子命令
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	root := fs.String("root", ".", "仓库根目录")
+	list := fs.Bool("list", false, "只列出发现的基准测试")
+	fs.Parse(args)
+	if fs.NArg() > 1 || (fs.NArg() == 0 && !*list) {
+		return fmt.Errorf("用法: study bench [-root 目录] [-list] [文件或ID]")
+	}
+
+	cat, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	b := bench.New(cat, runner.New(bench.RunnerConfig()))
+	var suites []bench.Suite
+	for _, s := range b.Suites() {
+		if fs.NArg() == 0 || s.ID == fs.Arg(0) || s.Path == fs.Arg(0) {
+			suites = append(suites, s)
+		}
+	}
+	if *list {
+		for _, s := range suites {
+			fmt.Printf("%s\t%s\n", s.ID, strings.Join(s.Benchmarks, ", "))
+		}
+		return nil
+	}
+	if len(suites) == 0 {
+		return bench.ErrUnknownSuite.With(fs.Arg(0))
+	}
+
+	regressions := 0
+	for _, s := range suites {
+		fmt.Printf("⏱️ %s\n", s.ID)
+		report, err := b.Run(context.Background(), s.ID)
+		if err != nil {
+			return err
+		}
+		changes := make(map[string]bench.Change)
+		for _, c := range report.Changes {
+			changes[c.Name] = c
+		}
+		for _, res := range report.Results {
+			fmt.Printf("  %-28s %10d %12.1f ns/op %8d B/op %6d allocs/op", res.Name, res.N, res.NsPerOp, res.BytesPerOp, res.AllocsPerOp)
+			if c, ok := changes[res.Name]; ok {
+				mark := ""
+				if c.Regression {
+					mark = " ⚠️ 回退"
+				}
+				fmt.Printf("  (ns %+.1f%%, B %+.1f%%, allocs %+d%s)", c.NsDelta*100, c.BytesDelta*100, c.AllocsDelta, mark)
+			}
+			fmt.Println()
+		}
+		regressions += report.Regressions()
+	}
+	fmt.Printf("\n结果已保存到 %s\n", bench.HistoryFile)
+
+	if regressions > 0 {
+		fmt.Printf("⚠️ %d 个基准测试比上一次慢了 %.0f%% 以上或分配增加\n", regressions, bench.RegressionThreshold*100)
+		os.Exit(1)
+	}
+	return nil
+}
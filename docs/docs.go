@@ -0,0 +1,9 @@
+// Package docs 嵌入文档目录中的图片，供学习服务器作为静态资源提供
+package docs
+
+import "embed"
+
+// Images README 中引用的学习路线图
+//
+//go:embed 1.jpg 2.jpg
+var Images embed.FS
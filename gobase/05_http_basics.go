@@ -249,14 +249,15 @@ func demonstrateHTTPClient() {
 
 // 实用工具函数
 
-// WriteJSONResponse 写入JSON响应
-func WriteJSONResponse(w http.ResponseWriter, code int, data interface{}) {
+// WriteJSONResponse 写入JSON响应，消息使用请求的语言
+func WriteJSONResponse(w http.ResponseWriter, r *http.Request, code int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept-Language")
 	w.WriteHeader(code)
 	
 	response := Response{
 		Code:    code,
-		Message: getStatusMessage(code),
+		Message: getStatusMessage(code, requestLanguage(r)),
 		Data:    data,
 	}
 	
@@ -276,24 +277,57 @@ func WriteErrorResponse(w http.ResponseWriter, code int, message string) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// getStatusMessage 获取状态码对应的消息
-func getStatusMessage(code int) string {
-	switch code {
-	case 200:
-		return "成功"
-	case 201:
-		return "创建成功"
-	case 400:
-		return "请求错误"
-	case 401:
-		return "未授权"
-	case 404:
-		return "未找到"
-	case 500:
-		return "服务器错误"
-	default:
-		return "未知状态"
+// statusMessages 状态码对应的消息，按语言区分；0 表示未知状态
+var statusMessages = map[string]map[int]string{
+	"zh": {
+		200: "成功",
+		201: "创建成功",
+		400: "请求错误",
+		401: "未授权",
+		404: "未找到",
+		500: "服务器错误",
+		0:   "未知状态",
+	},
+	"en": {
+		200: "OK",
+		201: "Created",
+		400: "Bad Request",
+		401: "Unauthorized",
+		404: "Not Found",
+		500: "Internal Server Error",
+		0:   "Unknown Status",
+	},
+}
+
+// requestLanguage 选择响应语言：?lang= 参数优先，其次是 lang Cookie 和 Accept-Language 请求头，默认中文
+func requestLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); statusMessages[lang] != nil {
+		return lang
+	}
+	if c, err := r.Cookie("lang"); err == nil && statusMessages[c.Value] != nil {
+		return c.Value
+	}
+	// Accept-Language: en-US,en;q=0.9,zh-CN;q=0.8 —— 这里简单地取第一个支持的语言，忽略 q 权重
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if statusMessages[tag] != nil {
+			return tag
+		}
+	}
+	return "zh"
+}
+
+// getStatusMessage 获取状态码在 lang 语言下对应的消息
+func getStatusMessage(code int, lang string) string {
+	messages, ok := statusMessages[lang]
+	if !ok {
+		messages = statusMessages["zh"]
+	}
+	if msg, ok := messages[code]; ok {
+		return msg
 	}
+	return messages[0]
 }
 
 // ParseJSONBody 解析JSON请求体
@@ -53,6 +53,21 @@ type APIResponse struct {
 	Error   string      `json:"error,omitempty"`
 }
 
+// ProblemDetails RFC 9457 问题详情，媒体类型为 application/problem+json
+// type、title、status、detail、instance 是标准成员，其余字段是扩展成员
+type ProblemDetails struct {
+	Type      string            `json:"type"`                 // 问题类型的 URI，没有专门说明时为 about:blank
+	Title     string            `json:"title"`                // 问题类型的简短说明，about:blank 时为状态码短语
+	Status    int               `json:"status"`               // HTTP 状态码
+	Detail    string            `json:"detail,omitempty"`     // 这一次错误的具体说明
+	Instance  string            `json:"instance,omitempty"`   // 出错的请求路径
+	Errors    map[string]string `json:"errors,omitempty"`     // 扩展成员：字段验证错误
+	RequestID string            `json:"request_id,omitempty"` // 扩展成员：请求 ID
+}
+
+// UseProblemJSON 服务器配置：为 true 时 WriteAPIError 总是输出 application/problem+json
+var UseProblemJSON = false
+
 // PaginationResponse 分页响应
 type PaginationResponse struct {
 	Items      interface{} `json:"items"`
@@ -318,6 +333,22 @@ func demonstrateErrorHandling() {
 	
 	errorJSON, _ := json.MarshalIndent(errorResponse, "", "  ")
 	fmt.Printf("%s\n", errorJSON)
+	
+	// RFC 9457 问题详情：标准化的错误格式，客户端通过 Accept: application/problem+json 请求
+	fmt.Println("\nRFC 9457 问题详情（application/problem+json）：")
+	problem := ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(http.StatusUnprocessableEntity),
+		Status:    http.StatusUnprocessableEntity,
+		Detail:    "数据验证失败",
+		Instance:  "/api/v1/books",
+		Errors:    map[string]string{"title": "title字段长度不能超过100个字符"},
+		RequestID: "7f3a9c2e",
+	}
+	
+	problemJSON, _ := json.MarshalIndent(problem, "", "  ")
+	fmt.Printf("%s\n", problemJSON)
+	fmt.Println("同一接口可以同时支持两种格式：Accept 头包含 application/problem+json 时返回问题详情，否则返回统一响应格式")
 }
 
 // 6. 数据验证演示
@@ -553,8 +584,14 @@ func WriteAPIResponse(w http.ResponseWriter, code int, success bool, message str
 	json.NewEncoder(w).Encode(response)
 }
 
-// WriteAPIError 写入API错误响应
+// WriteAPIError 写入API错误响应；UseProblemJSON 为 true 时输出问题详情，
+// 需要按 Accept 头协商格式时使用 WriteAPIErrorFor
 func WriteAPIError(w http.ResponseWriter, code int, message string, err string) {
+	if UseProblemJSON {
+		WriteProblem(w, nil, code, err, nil)
+		return
+	}
+	
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	
@@ -568,6 +605,35 @@ func WriteAPIError(w http.ResponseWriter, code int, message string, err string)
 	json.NewEncoder(w).Encode(response)
 }
 
+// WriteAPIErrorFor 写入API错误响应，按服务器配置和请求的 Accept 头选择格式：
+// 客户端接受 application/problem+json 时输出问题详情，否则输出 APIResponse
+func WriteAPIErrorFor(w http.ResponseWriter, r *http.Request, code int, message string, err string) {
+	if UseProblemJSON || strings.Contains(r.Header.Get("Accept"), "application/problem+json") {
+		WriteProblem(w, r, code, err, nil)
+		return
+	}
+	WriteAPIError(w, code, message, err)
+}
+
+// WriteProblem 写入 RFC 9457 问题详情，fields 为字段验证错误（可以为 nil）
+func WriteProblem(w http.ResponseWriter, r *http.Request, code int, detail string, fields map[string]string) {
+	problem := ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(code),
+		Status: code,
+		Detail: detail,
+		Errors: fields,
+	}
+	if r != nil {
+		problem.Instance = r.URL.Path
+		problem.RequestID = r.Header.Get("X-Request-Id")
+	}
+	
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(problem)
+}
+
 // ParsePaginationParams 解析分页参数
 func ParsePaginationParams(r *http.Request) (page, pageSize int) {
 	pageStr := r.URL.Query().Get("page")
@@ -0,0 +1,154 @@
+// Package apperr 应用错误模型：错误类别决定 HTTP 状态码，稳定的错误码供客户端判断，
+// 面向用户的消息按请求语言翻译，内部原因只写日志不返回给客户端
+package apperr
+
+import (
+	"errors"
+	"strings"
+
+	"go-web-api-study/internal/i18n"
+)
+
+// Kind 错误类别，零值为 Internal
+type Kind int
+
+// 错误类别
+const (
+	Internal     Kind = iota // 服务器内部错误，消息不返回给客户端
+	NotFound                 // 资源不存在
+	Conflict                 // 与已有资源冲突，如用户名已存在
+	Validation               // 请求参数错误
+	Unauthorized             // 未登录或凭据错误
+	Forbidden                // 已登录但没有权限
+	RateLimited              // 请求过多或资源繁忙
+)
+
+var kindNames = map[Kind]string{
+	Internal:     "internal",
+	NotFound:     "not_found",
+	Conflict:     "conflict",
+	Validation:   "validation",
+	Unauthorized: "unauthorized",
+	Forbidden:    "forbidden",
+	RateLimited:  "rate_limited",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return kindNames[Internal]
+}
+
+// FieldError 一个字段的错误，Rule 对应消息目录中的 validation.<Rule>
+type FieldError struct {
+	Field   string `json:"field"`           // JSON、path 或 query 中的字段名
+	Rule    string `json:"rule"`            // 未通过的规则，如 required、min_len
+	Param   string `json:"param,omitempty"` // 规则参数，如 min=3 中的 3
+	Message string `json:"message,omitempty"`
+}
+
+// Localize 返回填写了语言 l 下消息的副本
+func (f FieldError) Localize(l i18n.Locale) FieldError {
+	f.Message = i18n.T(l, "validation."+f.Rule, f.Field, f.Param)
+	return f
+}
+
+// Error 应用错误。作为包级哨兵错误定义，用 With 填写消息参数、Wrap 附加内部原因，
+// errors.Is 按错误码比较，errors.As 可以取出 *Error 或消息中的 *i18n.Error
+type Error struct {
+	Kind   Kind
+	Code   string       // 稳定的错误码，如 user_not_found
+	Fields []FieldError // 字段级错误详情，通常用于 Validation
+
+	msg   *i18n.Error // 面向用户的消息
+	cause error       // 内部原因
+}
+
+// New 创建应用错误，key 和 args 为消息目录中的键和参数
+func New(kind Kind, code, key string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Code: code, msg: i18n.NewError(key, args...)}
+}
+
+// Invalid 请求参数校验失败，包含全部未通过的字段
+func Invalid(fields ...FieldError) *Error {
+	e := New(Validation, "validation_failed", "validation.failed")
+	e.Fields = fields
+	return e
+}
+
+// From 把任意错误转换为 *Error：错误链中已有 *Error 时返回它，否则作为内部错误包装
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Kind: Internal, Code: "internal", msg: i18n.NewError("request.internal"), cause: err}
+}
+
+// KindOf 返回错误的类别，不是 *Error 时为 Internal
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
+
+// With 返回带消息参数的同类错误
+func (e *Error) With(args ...interface{}) *Error {
+	c := *e
+	c.msg = e.msg.With(args...)
+	return &c
+}
+
+// Wrap 返回附加了内部原因的同类错误，原因只出现在 Error() 和日志中
+func (e *Error) Wrap(cause error) *Error {
+	c := *e
+	c.cause = cause
+	return &c
+}
+
+// Error 中文消息，有内部原因时附在后面，用于日志
+func (e *Error) Error() string {
+	s := e.Message(i18n.Default)
+	if e.cause != nil {
+		s += ": " + e.cause.Error()
+	}
+	return s
+}
+
+// Message 语言 l 下面向用户的消息；有字段错误时为各字段消息的拼接
+func (e *Error) Message(l i18n.Locale) string {
+	if len(e.Fields) == 0 {
+		return i18n.T(l, e.msg.Key, e.msg.Args...)
+	}
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Localize(l).Message)
+	}
+	return strings.Join(msgs, i18n.T(l, "common.separator"))
+}
+
+// LocalizedFields 填写了语言 l 下消息的字段错误
+func (e *Error) LocalizedFields(l i18n.Locale) []FieldError {
+	fields := make([]FieldError, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = f.Localize(l)
+	}
+	return fields
+}
+
+// Is 错误码相同即视为同一错误，带参数或原因的错误也能与哨兵错误比较
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Unwrap 返回消息和内部原因，使 errors.Is/As 能找到两者
+func (e *Error) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.msg}
+	}
+	return []error{e.msg, e.cause}
+}
@@ -0,0 +1,450 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/fsutil"
+	"go-web-api-study/internal/runner"
+)
+
+// HistoryFile 基准测试历史，相对仓库根目录
+const HistoryFile = ".study/bench.json"
+
+// maxRunsPerSuite 每组基准测试保留的历史运行次数
+const maxRunsPerSuite = 50
+
+// RegressionThreshold ns/op 或 B/op 比上一次增加超过这个比例时视为性能回退
+const RegressionThreshold = 0.15
+
+// resultMarker 测试程序输出结果时使用的行标记
+const resultMarker = "@@study-bench@@"
+
+// harnessImports 追加在 package 子句同一行的导入，使用别名避免与学习文件中的名字冲突，且不改变行号
+const harnessImports = `; import studyfmt "fmt"; import studyruntime "runtime"; import studytesting "testing"`
+
+// 基准测试错误，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrUnknownSuite 基准测试不存在，参数为 Suite.ID
+	ErrUnknownSuite = apperr.New(apperr.NotFound, "bench_suite_not_found", "bench.unknown_suite")
+	// ErrRunFailed 基准测试程序编译或运行失败，参数为阶段、退出码和错误输出；
+	// 超时、没有结果和无法解析的源码使用同一个错误码，errors.Is 都能匹配
+	ErrRunFailed = apperr.New(apperr.Validation, "bench_run_failed", "bench.run_failed")
+
+	errTimedOut    = apperr.New(apperr.Validation, ErrRunFailed.Code, "bench.timed_out")
+	errNoResults   = apperr.New(apperr.Validation, ErrRunFailed.Code, "bench.no_results")
+	errParseFailed = apperr.New(apperr.Validation, ErrRunFailed.Code, "bench.parse_failed")
+)
+
+// stdImports 示例代码中常用的标准库包名到导入路径，示例代码没有 import 语句，按用到的包名补上
+var stdImports = map[string]string{
+	"atomic":  "sync/atomic",
+	"bytes":   "bytes",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"json":    "encoding/json",
+	"math":    "math",
+	"rand":    "math/rand",
+	"regexp":  "regexp",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"sync":    "sync",
+	"testing": "testing",
+	"time":    "time",
+	"unicode": "unicode",
+	"utf8":    "unicode/utf8",
+}
+
+// Result 单个基准测试的结果
+type Result struct {
+	Name        string  `json:"name"`
+	N           int     `json:"n"` // 循环次数
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// Run 一次运行的记录
+type Run struct {
+	Suite     string    `json:"suite"`
+	Time      time.Time `json:"time"`
+	GoVersion string    `json:"go_version"`
+	Results   []Result  `json:"results"`
+}
+
+// Result 按名称查找结果
+func (r *Run) Result(name string) (Result, bool) {
+	for _, res := range r.Results {
+		if res.Name == name {
+			return res, true
+		}
+	}
+	return Result{}, false
+}
+
+// Change 与上一次运行相比的变化，比例为 (本次 - 上次) / 上次
+type Change struct {
+	Name        string  `json:"name"`
+	Previous    Result  `json:"previous"`
+	Current     Result  `json:"current"`
+	NsDelta     float64 `json:"ns_delta"`
+	BytesDelta  float64 `json:"bytes_delta"`
+	AllocsDelta int64   `json:"allocs_delta"`
+	Regression  bool    `json:"regression"`
+}
+
+// Report 一次运行及其与上一次运行的比较
+type Report struct {
+	Run
+	Previous *time.Time `json:"previous,omitempty"` // 上一次运行的时间，第一次运行时为空
+	Changes  []Change   `json:"changes"`
+}
+
+// Regressions 回退的基准测试数
+func (r *Report) Regressions() int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Regression {
+			n++
+		}
+	}
+	return n
+}
+
+// Bench 运行学习文件中的基准测试并保存历史
+type Bench struct {
+	cat *catalog.Catalog
+	run *runner.Runner
+
+	mu sync.Mutex // 保护历史文件的读改写
+}
+
+// RunnerConfig 运行基准测试的配置：每个基准测试至少运行 1 秒，总时长上限放宽到 2 分钟；
+// 同一时间只运行一组，避免相互干扰测量结果
+func RunnerConfig() runner.Config {
+	cfg := runner.DefaultConfig()
+	cfg.Timeout = 2 * time.Minute
+	cfg.MaxConcurrent = 1
+	return cfg
+}
+
+// New 创建基准测试服务，run 通常使用 RunnerConfig 创建
+func New(cat *catalog.Catalog, run *runner.Runner) *Bench {
+	return &Bench{cat: cat, run: run}
+}
+
+// Suites 当前目录中的全部基准测试
+func (b *Bench) Suites() []Suite {
+	return Discover(b.cat)
+}
+
+// Suite 按 ID 或文件路径查找基准测试，文件中有多组时返回第一组
+func (b *Bench) Suite(id string) (Suite, error) {
+	for _, s := range b.Suites() {
+		if s.ID == id || s.Path == id {
+			return s, nil
+		}
+	}
+	return Suite{}, ErrUnknownSuite.With(id)
+}
+
+// Run 编译运行一组基准测试，把结果追加到历史中并与上一次运行比较
+func (b *Bench) Run(ctx context.Context, id string) (*Report, error) {
+	suite, err := b.Suite(id)
+	if err != nil {
+		return nil, err
+	}
+	src, err := b.harness(suite)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr strings.Builder
+	result, err := b.run.RunSource(ctx, suite.ID, src, func(ev runner.Event) {
+		switch ev.Stream {
+		case runner.StreamStdout:
+			stdout.WriteString(ev.Data)
+		case runner.StreamStderr:
+			stderr.WriteString(ev.Data)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.TimedOut {
+		return nil, errTimedOut.With(result.Stage)
+	}
+	if !result.OK() {
+		return nil, ErrRunFailed.With(result.Stage, result.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	run := parseRun(stdout.String())
+	run.Suite = suite.ID
+	run.Time = time.Now()
+	if len(run.Results) == 0 {
+		return nil, errNoResults
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	runs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Run: run, Changes: []Change{}}
+	if prev := latest(runs, suite.ID); prev != nil {
+		report.Previous = &prev.Time
+		report.Changes = Compare(prev, &run)
+	}
+	if err := b.save(trim(append(runs, run))); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// History 一组基准测试的历史运行，按时间从早到晚；id 为空时返回全部
+func (b *Bench) History(id string) ([]Run, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	runs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return runs, nil
+	}
+	filtered := []Run{}
+	for _, r := range runs {
+		if r.Suite == id {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// Compare 比较两次运行中同名的基准测试
+func Compare(prev, cur *Run) []Change {
+	changes := []Change{}
+	for _, c := range cur.Results {
+		p, ok := prev.Result(c.Name)
+		if !ok {
+			continue
+		}
+		change := Change{
+			Name:        c.Name,
+			Previous:    p,
+			Current:     c,
+			NsDelta:     ratio(p.NsPerOp, c.NsPerOp),
+			BytesDelta:  ratio(float64(p.BytesPerOp), float64(c.BytesPerOp)),
+			AllocsDelta: c.AllocsPerOp - p.AllocsPerOp,
+		}
+		change.Regression = change.NsDelta > RegressionThreshold || change.BytesDelta > RegressionThreshold || change.AllocsDelta > 0
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// ratio 变化比例，上次为 0 时只要本次大于 0 就视为增加 100%
+func ratio(prev, cur float64) float64 {
+	if prev == 0 {
+		if cur > 0 {
+			return 1
+		}
+		return 0
+	}
+	return (cur - prev) / prev
+}
+
+// harness 生成基准测试程序：顶层函数保留整个文件并把原来的 main 改名，
+// 示例代码按用到的包补上导入；新的 main 用 testing.Benchmark 逐个运行并打印结果
+func (b *Bench) harness(suite Suite) ([]byte, error) {
+	var src strings.Builder
+	if suite.Embedded {
+		src.WriteString("package main" + harnessImports + "\n\nimport (\n")
+		for _, path := range snippetImports(suite.code) {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		}
+		src.WriteString(")\n")
+		src.WriteString(suite.code)
+	} else {
+		data, err := os.ReadFile(filepath.Join(b.cat.Root(), filepath.FromSlash(suite.Path)))
+		if err != nil {
+			return nil, err
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, suite.Path, data, 0)
+		if err != nil {
+			return nil, errParseFailed.With(err.Error())
+		}
+		// 先把原来的 main 改名，再在 package 子句的同一行追加导入（在 main 之前，不影响改名的偏移）
+		for _, d := range file.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+				data = splice(data, fset.Position(fn.Name.Pos()).Offset, len("main"), "studyOriginalMain")
+			}
+		}
+		data = splice(data, fset.Position(file.Name.End()).Offset, 0, harnessImports)
+		src.Write(data)
+	}
+
+	src.WriteString("\n\nfunc main() {\n")
+	fmt.Fprintf(&src, "\tstudyfmt.Printf(\"\\n%s\\tgo\\t%%s\\n\", studyruntime.Version())\n", resultMarker)
+	for _, name := range suite.Benchmarks {
+		fmt.Fprintf(&src, "\tstudyBench(%q, %s)\n", name, name)
+	}
+	fmt.Fprintf(&src, `}
+
+func studyBench(name string, f func(*studytesting.B)) {
+	r := studytesting.Benchmark(f)
+	studyfmt.Printf("\n%s\t%%s\t%%d\t%%d\t%%d\t%%d\n", name, r.N, r.T.Nanoseconds(), r.MemBytes, r.MemAllocs)
+}
+`, resultMarker)
+	return []byte(src.String()), nil
+}
+
+// splice 把 data[offset:offset+remove] 替换为 text
+func splice(data []byte, offset, remove int, text string) []byte {
+	out := make([]byte, 0, len(data)+len(text))
+	out = append(out, data[:offset]...)
+	out = append(out, text...)
+	return append(out, data[offset+remove:]...)
+}
+
+// snippetImports 示例代码用到的标准库包
+func snippetImports(code string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package main\n"+code, 0)
+	if err != nil {
+		return nil
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				if path, ok := stdImports[id.Name]; ok {
+					used[path] = true
+				}
+			}
+		}
+		return true
+	})
+	paths := make([]string, 0, len(used))
+	for path := range used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// parseRun 从程序输出中解析 Go 版本和每个基准测试的结果，失败的基准测试（N 为 0）跳过
+func parseRun(stdout string) Run {
+	run := Run{Results: []Result{}}
+	sc := bufio.NewScanner(strings.NewReader(stdout))
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		i := strings.Index(line, resultMarker+"\t")
+		if i < 0 {
+			continue
+		}
+		fields := strings.Split(line[i+len(resultMarker)+1:], "\t")
+		if len(fields) == 2 && fields[0] == "go" {
+			run.GoVersion = fields[1]
+			continue
+		}
+		if len(fields) != 5 {
+			continue
+		}
+		var nums [4]int64
+		ok := true
+		for j := range nums {
+			v, err := strconv.ParseInt(fields[j+1], 10, 64)
+			ok = ok && err == nil
+			nums[j] = v
+		}
+		if !ok || nums[0] <= 0 {
+			continue
+		}
+		n := nums[0]
+		run.Results = append(run.Results, Result{
+			Name:        fields[0],
+			N:           int(n),
+			NsPerOp:     float64(nums[1]) / float64(n),
+			BytesPerOp:  nums[2] / n,
+			AllocsPerOp: nums[3] / n,
+		})
+	}
+	return run
+}
+
+// latest 一组基准测试最近的一次运行
+func latest(runs []Run, suite string) *Run {
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Suite == suite {
+			return &runs[i]
+		}
+	}
+	return nil
+}
+
+// trim 每组基准测试只保留最近 maxRunsPerSuite 次运行
+func trim(runs []Run) []Run {
+	count := make(map[string]int)
+	kept := make([]Run, 0, len(runs))
+	for i := len(runs) - 1; i >= 0; i-- {
+		count[runs[i].Suite]++
+		if count[runs[i].Suite] <= maxRunsPerSuite {
+			kept = append(kept, runs[i])
+		}
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return kept
+}
+
+// path 历史文件在磁盘上的路径
+func (b *Bench) path() string {
+	return filepath.Join(b.cat.Root(), filepath.FromSlash(HistoryFile))
+}
+
+// load 读取历史文件，不存在时为空
+func (b *Bench) load() ([]Run, error) {
+	data, err := os.ReadFile(b.path())
+	if os.IsNotExist(err) {
+		return []Run{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", HistoryFile, err)
+	}
+	return runs, nil
+}
+
+// save 原子地写回历史文件
+func (b *Bench) save(runs []Run) error {
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path()), 0o755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(b.path(), data, 0o644)
+}
@@ -0,0 +1,121 @@
+// Package bench 发现学习文件中的 Benchmark 函数，用 testing.Benchmark 在临时目录中运行，
+// 把 ns/op、B/op、allocs/op 记录到本地历史中，并与上一次运行比较找出性能回退
+package bench
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-web-api-study/internal/catalog"
+)
+
+// Suite 一组一起运行的基准测试：文件中的顶层 Benchmark 函数，
+// 或写在字符串字面量中的示例代码（如 08_advanced_features.go 中打印的基准测试示例）
+type Suite struct {
+	ID         string   `json:"id"` // gobase/08_advanced_features.go，示例代码为 gobase/08_advanced_features.go#L786
+	Path       string   `json:"path"`
+	Line       int      `json:"line"`
+	Embedded   bool     `json:"embedded"` // 示例代码单独编译，只包含字符串中的代码
+	Benchmarks []string `json:"benchmarks"`
+	URL        string   `json:"url"` // 源码页面中对应的行
+
+	code string // 示例代码的内容
+}
+
+// Discover 扫描 gobase 模块和练习中的 Benchmark 函数，无法解析的文件跳过
+func Discover(cat *catalog.Catalog) []Suite {
+	var suites []Suite
+	for _, kind := range []catalog.Kind{catalog.KindGobase, catalog.KindExercise} {
+		for _, e := range cat.Entries(kind) {
+			if !strings.HasSuffix(e.Path, ".go") {
+				continue
+			}
+			src, err := os.ReadFile(filepath.Join(cat.Root(), filepath.FromSlash(e.Path)))
+			if err != nil {
+				continue
+			}
+			suites = append(suites, discoverFile(e, src)...)
+		}
+	}
+	return suites
+}
+
+// discoverFile 找出一个文件中的顶层 Benchmark 函数和包含 Benchmark 函数的原始字符串
+func discoverFile(e catalog.Entry, src []byte) []Suite {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, e.Path, src, 0)
+	if err != nil {
+		return nil
+	}
+
+	var suites []Suite
+	if names, line := benchmarkFuncs(fset, file); len(names) > 0 {
+		suites = append(suites, Suite{ID: e.Path, Path: e.Path, Line: line, Benchmarks: names, URL: e.Route + "#L" + strconv.Itoa(line)})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING || !strings.HasPrefix(lit.Value, "`") || !strings.Contains(lit.Value, "func Benchmark") {
+			return true
+		}
+		code, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		snippet, err := parser.ParseFile(token.NewFileSet(), "", "package main\n"+code, 0)
+		if err != nil {
+			return true
+		}
+		if names, _ := benchmarkFuncs(nil, snippet); len(names) > 0 {
+			line := fset.Position(lit.Pos()).Line
+			suites = append(suites, Suite{
+				ID:         e.Path + "#L" + strconv.Itoa(line),
+				Path:       e.Path,
+				Line:       line,
+				Embedded:   true,
+				Benchmarks: names,
+				URL:        e.Route + "#L" + strconv.Itoa(line),
+				code:       code,
+			})
+		}
+		return true
+	})
+	return suites
+}
+
+// benchmarkFuncs 返回 func BenchmarkXxx(b *testing.B) 形式的顶层函数名，以及第一个函数的行号
+func benchmarkFuncs(fset *token.FileSet, file *ast.File) (names []string, line int) {
+	for _, d := range file.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Benchmark") || !takesB(fn.Type) {
+			continue
+		}
+		if len(names) == 0 && fset != nil {
+			line = fset.Position(fn.Pos()).Line
+		}
+		names = append(names, fn.Name.Name)
+	}
+	return names, line
+}
+
+// takesB 函数只有一个 *testing.B 参数且没有返回值
+func takesB(ft *ast.FuncType) bool {
+	if ft.TypeParams != nil || ft.Results != nil || len(ft.Params.List) != 1 || len(ft.Params.List[0].Names) > 1 {
+		return false
+	}
+	star, ok := ft.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "testing" && sel.Sel.Name == "B"
+}
@@ -0,0 +1,219 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"net/http"
+	"strings"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// RunRequest 运行基准测试的请求
+type RunRequest struct {
+	Suite string `json:"suite"` // Suite.ID 或文件路径
+}
+
+// APIHandler /api/v1/bench：GET 列出基准测试和历史（?suite= 只看一组），POST RunRequest 运行并返回与上次的比较
+func (b *Bench) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			history, err := b.History(r.URL.Query().Get("suite"))
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, map[string]interface{}{
+				"suites":  b.Suites(),
+				"history": history,
+			})
+
+		case http.MethodPost:
+			var req RunRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil || req.Suite == "" {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			report, err := b.Run(r.Context(), req.Suite)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, report)
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// writeError 把基准测试错误映射为状态码：程序编译或运行失败为 422，其他按 apperr 类别
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrRunFailed) {
+		handler.ErrorResponseFrom(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+	handler.WriteError(w, r, err)
+}
+
+// chartColors 图表中各基准测试的颜色
+var chartColors = []string{"#007acc", "#e07b00", "#2e7d32", "#c62828", "#6a1b9a", "#00838f"}
+
+// suiteView 页面中的一组基准测试，有运行记录时包含最近一次的结果和图表
+type suiteView struct {
+	Suite
+	Runs      int
+	Latest    *Run
+	Rows      []latestRow
+	BarChart  template.HTML
+	LineChart template.HTML // 运行过两次以上才有趋势图
+}
+
+// latestRow 最近一次结果表格中的一行，Delta 为与上次相比的变化，第一次运行时为空
+type latestRow struct {
+	Result
+	Delta string
+	Class string // regression 或 improvement
+}
+
+// PageHandler /bench 基准测试列表、最近一次结果、与上次的比较和历史趋势图
+func (b *Bench) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		history, err := b.History("")
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+
+		var suites []suiteView
+		for _, s := range b.Suites() {
+			var runs []Run
+			for _, run := range history {
+				if run.Suite == s.ID {
+					runs = append(runs, run)
+				}
+			}
+			view := suiteView{Suite: s, Runs: len(runs)}
+			if len(runs) > 0 {
+				view.Latest = &runs[len(runs)-1]
+				view.Rows = latestRows(runs)
+				view.BarChart = barChart(view.Latest.Results)
+				if len(runs) > 1 {
+					view.LineChart = lineChart(runs)
+				}
+			}
+			suites = append(suites, view)
+		}
+
+		pages.Render(w, r, "bench", struct {
+			Suites      []suiteView
+			HistoryFile string
+			Threshold   float64
+		}{suites, HistoryFile, RegressionThreshold * 100})
+	}
+}
+
+// latestRows 最近一次运行的结果，有上一次运行时附上变化
+func latestRows(runs []Run) []latestRow {
+	cur := runs[len(runs)-1]
+	changes := make(map[string]Change)
+	if len(runs) > 1 {
+		for _, c := range Compare(&runs[len(runs)-2], &cur) {
+			changes[c.Name] = c
+		}
+	}
+	rows := make([]latestRow, 0, len(cur.Results))
+	for _, res := range cur.Results {
+		row := latestRow{Result: res}
+		if c, ok := changes[res.Name]; ok {
+			row.Delta = fmt.Sprintf("ns %+.1f%% · B %+.1f%% · allocs %+d", c.NsDelta*100, c.BytesDelta*100, c.AllocsDelta)
+			switch {
+			case c.Regression:
+				row.Class = "regression"
+			case c.NsDelta < -RegressionThreshold:
+				row.Class = "improvement"
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// barChart 横向条形图，比较同一次运行中各基准测试的 ns/op；名字已转义
+func barChart(results []Result) template.HTML {
+	const width, barHeight, labelWidth = 520, 22, 200
+	max := 0.0
+	for _, res := range results {
+		max = math.Max(max, res.NsPerOp)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%d" height="%d" font-size="12" font-family="Arial">`, width, len(results)*(barHeight+6)+4)
+	for i, res := range results {
+		y := i*(barHeight+6) + 2
+		w := 0.0
+		if max > 0 {
+			w = res.NsPerOp / max * (width - labelWidth - 80)
+		}
+		fmt.Fprintf(&b, `<text x="0" y="%d">%s</text>`, y+15, html.EscapeString(res.Name))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"/>`, labelWidth, y, w, barHeight, chartColors[i%len(chartColors)])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%.1f ns</text>`, float64(labelWidth)+w+5, y+15, res.NsPerOp)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// lineChart 折线图，每个基准测试一条 ns/op 随运行次数变化的折线
+func lineChart(runs []Run) template.HTML {
+	const width, height, left, bottom, top, right = 560, 240, 70, 30, 10, 10
+	var names []string
+	seen := make(map[string]bool)
+	max := 0.0
+	for _, run := range runs {
+		for _, res := range run.Results {
+			if !seen[res.Name] {
+				seen[res.Name] = true
+				names = append(names, res.Name)
+			}
+			max = math.Max(max, res.NsPerOp)
+		}
+	}
+	if max == 0 {
+		max = 1
+	}
+	x := func(i int) float64 {
+		return left + float64(i)*(width-left-right)/float64(len(runs)-1)
+	}
+	y := func(v float64) float64 {
+		return top + (1-v/max)*(height-top-bottom)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%d" height="%d" font-size="11" font-family="Arial">`, width, height+18*len(names))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`, left, height-bottom, width-right, height-bottom)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`, left, top, left, height-bottom)
+	fmt.Fprintf(&b, `<text x="0" y="%d">%.0f ns</text><text x="0" y="%d">0</text>`, top+10, max, height-bottom)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, left, height-bottom+15, runs[0].Time.Format("01-02 15:04"))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-right, height-bottom+15, runs[len(runs)-1].Time.Format("01-02 15:04"))
+	for i, name := range names {
+		color := chartColors[i%len(chartColors)]
+		var points []string
+		for j, run := range runs {
+			if res, ok := run.Result(name); ok {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(j), y(res.NsPerOp)))
+				fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s %s: %.1f ns/op</title></circle>`,
+					x(j), y(res.NsPerOp), color, html.EscapeString(name), run.Time.Format("2006-01-02 15:04:05"), res.NsPerOp)
+			}
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), color)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/><text x="%d" y="%d">%s</text>`,
+			left, height+18*i, color, left+18, height+18*i+10, html.EscapeString(name))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
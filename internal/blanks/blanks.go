@@ -0,0 +1,364 @@
+package blanks
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/fsutil"
+	"go-web-api-study/internal/scaffold"
+)
+
+// Level 难度，决定挖掉多少个函数体
+type Level string
+
+const (
+	LevelEasy   Level = "easy"   // 约三分之一
+	LevelMedium Level = "medium" // 约三分之二
+	LevelHard   Level = "hard"   // 全部
+)
+
+// ratios 各难度挖掉的函数比例
+var ratios = map[Level]float64{
+	LevelEasy:   1.0 / 3,
+	LevelMedium: 2.0 / 3,
+	LevelHard:   1,
+}
+
+// 填空练习错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrDayNotEmpty 目标练习目录中已经有 Go 文件：同一个 package main 中不能再有一个 main 和同名函数
+	ErrDayNotEmpty = apperr.New(apperr.Conflict, "day_not_empty", "blanks.day_not_empty")
+	// ErrUnknownModule gobase 模块不存在
+	ErrUnknownModule = apperr.New(apperr.NotFound, "module_not_found", "blanks.unknown_module")
+	// ErrUnknownFunc 指定的函数不在模块中
+	ErrUnknownFunc = apperr.New(apperr.NotFound, "func_not_found", "blanks.unknown_func")
+	// ErrInvalidDay 天数不是正整数
+	ErrInvalidDay = apperr.New(apperr.Validation, "invalid_day", "blanks.invalid_day")
+	// ErrInvalidLevel 不认识的难度
+	ErrInvalidLevel = apperr.New(apperr.Validation, "invalid_level", "blanks.invalid_level")
+)
+
+// Func 模块中可以挖空的函数
+type Func struct {
+	Name  string `json:"name"` // 方法为 Recv.Name
+	Line  int    `json:"line"`
+	Lines int    `json:"lines"` // 函数体行数
+}
+
+// Options 生成填空练习的参数
+type Options struct {
+	Module string   `json:"module"` // gobase 文件名，如 02_slices_maps.go
+	Day    int      `json:"day"`    // 写入 exercises/dayNN/
+	Level  Level    `json:"level"`  // 为空时为 medium；指定 Funcs 时忽略
+	Funcs  []string `json:"funcs"`  // 指定要挖空的函数
+	Name   string   `json:"name"`   // 文件名（不含 .go），默认为 <模块名>_blanks
+}
+
+// Result 生成的填空练习
+type Result struct {
+	Path     string   `json:"path"`
+	Route    string   `json:"route"`
+	Module   string   `json:"module"`
+	Level    Level    `json:"level,omitempty"`
+	Stripped []string `json:"stripped"` // 被挖空的函数
+	Kept     []string `json:"kept"`     // 保留实现的函数
+}
+
+// Candidates 列出 gobase 模块中除 main 以外、有函数体的顶层函数和方法
+func Candidates(cat *catalog.Catalog, module string) ([]Func, error) {
+	_, fset, file, err := parseModule(cat, module)
+	if err != nil {
+		return nil, err
+	}
+	var funcs []Func
+	for _, fn := range funcDecls(file) {
+		funcs = append(funcs, Func{
+			Name:  funcName(fn),
+			Line:  fset.Position(fn.Pos()).Line,
+			Lines: fset.Position(fn.Body.Rbrace).Line - fset.Position(fn.Body.Lbrace).Line - 1,
+		})
+	}
+	return funcs, nil
+}
+
+// Generate 把 gobase 模块中选中的函数体替换为 TODO 和 panic("not implemented")，
+// 保留函数签名和文档注释，去掉因此不再使用的导入，写入 exercises/dayNN/<name>.go。
+// 生成的文件是带 main 的完整程序，与已有练习放在同一个包里会重复声明，所以目标目录中已有
+// Go 文件时返回 ErrDayNotEmpty
+func Generate(cat *catalog.Catalog, opts Options) (*Result, error) {
+	module := strings.TrimPrefix(opts.Module, "gobase/")
+	src, fset, file, err := parseModule(cat, module)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Day <= 0 {
+		return nil, ErrInvalidDay.With(opts.Day)
+	}
+	name := opts.Name
+	if name == "" {
+		name = strings.TrimSuffix(module, ".go") + "_blanks"
+	}
+	if !scaffold.ValidName(name) {
+		return nil, scaffold.ErrInvalidName.With(name)
+	}
+
+	decls := funcDecls(file)
+	level := opts.Level
+	var selected map[string]bool
+	if len(opts.Funcs) > 0 {
+		level = ""
+		if selected, err = selectByName(decls, opts.Funcs); err != nil {
+			return nil, err
+		}
+	} else {
+		if level == "" {
+			level = LevelMedium
+		}
+		ratio, ok := ratios[level]
+		if !ok {
+			return nil, ErrInvalidLevel.With(level)
+		}
+		selected = selectByLevel(decls, ratio)
+	}
+
+	result := &Result{Module: module, Level: level, Stripped: []string{}, Kept: []string{}}
+	var edits []edit
+	for _, fn := range decls {
+		n := funcName(fn)
+		if !selected[n] {
+			result.Kept = append(result.Kept, n)
+			continue
+		}
+		result.Stripped = append(result.Stripped, n)
+		edits = append(edits, edit{
+			start: fset.Position(fn.Body.Lbrace).Offset,
+			end:   fset.Position(fn.Body.Rbrace).Offset + 1,
+			text:  fmt.Sprintf("{\n\t// TODO: 实现 %s\n\tpanic(\"not implemented\")\n}", n),
+		})
+	}
+
+	header := fmt.Sprintf("// 填空练习：由 gobase/%s 生成", module)
+	if level != "" {
+		header += "（难度 " + string(level) + "）"
+	}
+	header += "，补全标记为 TODO 的函数后运行并与 gobase 对比\n"
+	out, err := strip(header, src, edits)
+	if err != nil {
+		return nil, err
+	}
+
+	group := fmt.Sprintf("day%02d", opts.Day)
+	result.Path = "exercises/" + group + "/" + name + ".go"
+	result.Route = "/exercises/" + group + "/" + name
+	filename := filepath.Join(cat.Root(), filepath.FromSlash(result.Path))
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(filename), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		return nil, ErrDayNotEmpty.With("exercises/" + group)
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(filename, out, 0o644); err != nil {
+		return nil, err
+	}
+	if err := cat.Reload(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// edit 对源码的一次替换，[start, end) 为字节偏移
+type edit struct {
+	start, end int
+	text       string
+}
+
+// strip 应用替换、删除不再使用的导入并格式化
+func strip(header string, src []byte, edits []edit) ([]byte, error) {
+	out := apply(src, edits)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", out, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("生成的代码无法解析: %w", err)
+	}
+	used := usedPackages(file)
+	var removals []edit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		unused := 0
+		for _, spec := range gen.Specs {
+			is := spec.(*ast.ImportSpec)
+			if importUsed(is, used) {
+				continue
+			}
+			unused++
+			removals = append(removals, edit{
+				start: fset.Position(is.Pos()).Offset,
+				end:   fset.Position(is.End()).Offset,
+			})
+		}
+		// 整个 import 声明都不再需要时连同关键字一起删除
+		if unused == len(gen.Specs) {
+			removals = removals[:len(removals)-unused]
+			removals = append(removals, edit{
+				start: fset.Position(gen.Pos()).Offset,
+				end:   fset.Position(gen.End()).Offset,
+			})
+		}
+	}
+	out = apply(out, removals)
+
+	formatted, err := format.Source(append([]byte(header), out...))
+	if err != nil {
+		return nil, fmt.Errorf("格式化生成的代码失败: %w", err)
+	}
+	return formatted, nil
+}
+
+// apply 从后往前应用替换，保证前面的偏移不变
+func apply(src []byte, edits []edit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+	return out
+}
+
+// usedPackages 收集代码中以 pkg.X 形式引用的包名
+func usedPackages(file *ast.File) map[string]bool {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+// importUsed 判断导入是否仍被使用；空白导入和点导入总是保留
+func importUsed(is *ast.ImportSpec, used map[string]bool) bool {
+	if is.Name != nil {
+		return is.Name.Name == "_" || is.Name.Name == "." || used[is.Name.Name]
+	}
+	path, _ := strconv.Unquote(is.Path.Value)
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	// math/rand/v2 这类带版本后缀的路径使用上一级作为包名
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return used[name]
+}
+
+// parseModule 读取并解析 gobase 模块
+func parseModule(cat *catalog.Catalog, module string) ([]byte, *token.FileSet, *ast.File, error) {
+	module = strings.TrimPrefix(module, "gobase/")
+	e, ok := cat.LookupPath("gobase/" + module)
+	if !ok || e.Kind != catalog.KindGobase {
+		return nil, nil, nil, ErrUnknownModule.With(module)
+	}
+	src, err := os.ReadFile(filepath.Join(cat.Root(), filepath.FromSlash(e.Path)))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, e.Path, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("解析 %s 失败: %w", e.Path, err)
+	}
+	return src, fset, file, nil
+}
+
+// funcDecls 返回除 main 以外有非空函数体的函数声明
+func funcDecls(file *ast.File) []*ast.FuncDecl {
+	var decls []*ast.FuncDecl
+	for _, d := range file.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || len(fn.Body.List) == 0 {
+			continue
+		}
+		if fn.Recv == nil && (fn.Name.Name == "main" || fn.Name.Name == "init") {
+			continue
+		}
+		decls = append(decls, fn)
+	}
+	return decls
+}
+
+// funcName 返回函数名，方法为 Recv.Name
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return x.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+// selectByName 按名称选择函数，方法可以写成 Recv.Name 或只写方法名
+func selectByName(decls []*ast.FuncDecl, names []string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	for _, want := range names {
+		found := false
+		for _, fn := range decls {
+			n := funcName(fn)
+			if n == want || fn.Name.Name == want {
+				selected[n] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, ErrUnknownFunc.With(want)
+		}
+	}
+	return selected, nil
+}
+
+// selectByLevel 按比例在全部函数中均匀选取，至少选一个
+func selectByLevel(decls []*ast.FuncDecl, ratio float64) map[string]bool {
+	selected := make(map[string]bool)
+	n := len(decls)
+	if n == 0 {
+		return selected
+	}
+	count := max(1, int(float64(n)*ratio+0.5))
+	for i := 0; i < count; i++ {
+		selected[funcName(decls[i*n/count])] = true
+	}
+	return selected
+}
@@ -0,0 +1,43 @@
+package blanks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/handler"
+)
+
+// Handler /api/v1/blanks：
+// GET ?module=02_slices_maps.go 列出可以挖空的函数；
+// POST {"module": "02_slices_maps.go", "day": 3, "level": "easy"} 或 {"funcs": ["sliceCRUD"]} 生成填空练习，
+// 成功返回 201，目标练习目录中已有 Go 文件返回 409，模块或函数不存在返回 404
+func Handler(cat *catalog.Catalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			funcs, err := Candidates(cat, r.URL.Query().Get("module"))
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, funcs)
+
+		case http.MethodPost:
+			var opts Options
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&opts); err != nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			result, err := Generate(cat, opts)
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.CreatedResponse(w, result)
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package catalog
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind 目录条目类别
+type Kind string
+
+const (
+	KindGobase   Kind = "gobase"    // gobase/NN_*.go 基础模块
+	KindExercise Kind = "exercises" // exercises/dayNN/*.go 每日练习
+	KindDoc      Kind = "docs"      // Markdown 文档
+)
+
+// docDirs 参与索引的 Markdown 文档所在目录
+var docDirs = []string{".", "docs", "gobase", "exercises"}
+
+var (
+	gobaseFilePattern  = regexp.MustCompile(`^(\d{2})_[A-Za-z0-9_]+\.go$`)
+	exerciseDirPattern = regexp.MustCompile(`^day(\d{2,})$`)
+)
+
+// Entry 目录中的一个学习文件
+type Entry struct {
+	Kind    Kind   `json:"kind"`
+	ID      string `json:"id"`               // gobase: 01_variables_and_types; 练习: day03/variables_practice
+	Group   string `json:"group"`            // 练习所在的 dayNN 目录，gobase 为空
+	Order   int    `json:"order"`            // 文件名或目录名中的序号
+	Title   string `json:"title"`            // 取自文件开头注释的第一行
+	Summary string `json:"summary"`          // 开头注释的其余内容
+	Path    string `json:"path"`             // 相对仓库根目录的路径（使用 /）
+	Route   string `json:"route"`            // Web 访问路径
+	Module  string `json:"module,omitempty"` // gobase 模块在清单中的 ID
+}
+
+// Catalog 学习资料目录，扫描 gobase 和 exercises 生成有序索引
+type Catalog struct {
+	root string
+
+	mu       sync.RWMutex
+	entries  []Entry
+	byRoute  map[string]int
+	manifest *Manifest
+	plan     []PlanDay
+	loadedAt time.Time
+}
+
+// New 创建目录实例，root 为仓库根目录
+func New(root string) *Catalog {
+	return &Catalog{root: root}
+}
+
+// Load 创建目录并立即扫描一次
+func Load(root string) (*Catalog, error) {
+	c := New(root)
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Root 返回仓库根目录
+func (c *Catalog) Root() string {
+	return c.root
+}
+
+// Reload 重新读取清单和练习计划并扫描磁盘，校验通过后替换当前索引
+func (c *Catalog) Reload() error {
+	plan, err := ParsePlan(filepath.Join(c.root, "exercises", "README.md"))
+	if err != nil {
+		return err
+	}
+	manifest, err := LoadManifest(filepath.Join(c.root, "gobase", "manifest.json"))
+	if err != nil {
+		return err
+	}
+	if err := manifest.Validate(filepath.Join(c.root, "gobase"), plan); err != nil {
+		return err
+	}
+
+	gobase, err := c.scanGobase(manifest)
+	if err != nil {
+		return err
+	}
+	exercises, err := c.scanExercises()
+	if err != nil {
+		return err
+	}
+
+	docs, err := c.scanDocs()
+	if err != nil {
+		return err
+	}
+
+	entries := append(append(gobase, exercises...), docs...)
+	byRoute := make(map[string]int, len(entries))
+	for i, e := range entries {
+		byRoute[e.Route] = i
+	}
+
+	c.mu.Lock()
+	c.entries = entries
+	c.byRoute = byRoute
+	c.manifest = manifest
+	c.plan = plan
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// LoadedAt 返回最近一次扫描的时间
+func (c *Catalog) LoadedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.loadedAt
+}
+
+// Manifest 返回当前的 gobase 模块清单
+func (c *Catalog) Manifest() *Manifest {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.manifest
+}
+
+// Plan 返回 exercises/README.md 中的练习计划
+func (c *Catalog) Plan() []PlanDay {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.plan
+}
+
+// ModuleEntry 返回清单模块对应的目录条目
+func (c *Catalog) ModuleEntry(id string) (Entry, bool) {
+	for _, e := range c.Entries(KindGobase) {
+		if e.Module == id {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Entries 返回指定类别的条目（按序号排序），kind 为空时返回全部
+func (c *Catalog) Entries(kind Kind) []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		if kind == "" || e.Kind == kind {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Lookup 根据 Web 路径查找条目
+func (c *Catalog) Lookup(route string) (Entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, ok := c.byRoute[route]
+	if !ok {
+		return Entry{}, false
+	}
+	return c.entries[i], true
+}
+
+// LookupPath 根据相对仓库根目录的文件路径查找条目
+func (c *Catalog) LookupPath(p string) (Entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, e := range c.entries {
+		if e.Path == p {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Day 返回某一天（如 day03）下的全部练习文件
+func (c *Catalog) Day(group string) []Entry {
+	var result []Entry
+	for _, e := range c.Entries(KindExercise) {
+		if e.Group == group {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// scanGobase 扫描 gobase/NN_*.go，清单中的模块按清单顺序排在前面并使用清单中的标题
+func (c *Catalog) scanGobase(manifest *Manifest) ([]Entry, error) {
+	files, err := os.ReadDir(filepath.Join(c.root, "gobase"))
+	if err != nil {
+		return nil, fmt.Errorf("扫描 gobase 失败: %w", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		m := gobaseFilePattern.FindStringSubmatch(f.Name())
+		if f.IsDir() || m == nil {
+			continue
+		}
+		order, _ := strconv.Atoi(m[1])
+		id := strings.TrimSuffix(f.Name(), ".go")
+		e := Entry{
+			Kind:  KindGobase,
+			ID:    id,
+			Order: order,
+			Path:  path.Join("gobase", f.Name()),
+			Route: "/gobase/" + id,
+		}
+		if mod, ok := manifest.ModuleByFile(f.Name()); ok {
+			e.Module, e.Title, e.Summary = mod.ID, mod.Title, mod.Summary
+		} else {
+			e.Title, e.Summary = describe(filepath.Join(c.root, "gobase", f.Name()), id)
+		}
+		entries = append(entries, e)
+	}
+
+	rank := make(map[string]int, len(manifest.Modules))
+	for i, mod := range manifest.Modules {
+		rank[mod.ID] = i + 1
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ri, rj := rank[entries[i].Module], rank[entries[j].Module]
+		switch {
+		case ri != 0 && rj != 0:
+			return ri < rj
+		case ri != 0 || rj != 0:
+			return ri != 0
+		case entries[i].Order != entries[j].Order:
+			return entries[i].Order < entries[j].Order
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+// scanExercises 扫描 exercises/dayNN/*.go
+func (c *Catalog) scanExercises() ([]Entry, error) {
+	dirs, err := os.ReadDir(filepath.Join(c.root, "exercises"))
+	if err != nil {
+		return nil, fmt.Errorf("扫描 exercises 失败: %w", err)
+	}
+
+	var entries []Entry
+	for _, d := range dirs {
+		m := exerciseDirPattern.FindStringSubmatch(d.Name())
+		if !d.IsDir() || m == nil {
+			continue
+		}
+		order, _ := strconv.Atoi(m[1])
+
+		files, err := os.ReadDir(filepath.Join(c.root, "exercises", d.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("扫描 %s 失败: %w", d.Name(), err)
+		}
+		for _, f := range files {
+			name := f.Name()
+			if f.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			id := d.Name() + "/" + strings.TrimSuffix(name, ".go")
+			e := Entry{
+				Kind:  KindExercise,
+				ID:    id,
+				Group: d.Name(),
+				Order: order,
+				Path:  path.Join("exercises", d.Name(), name),
+				Route: "/exercises/" + id,
+			}
+			e.Title, e.Summary = describe(filepath.Join(c.root, "exercises", d.Name(), name), id)
+			entries = append(entries, e)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Order != entries[j].Order {
+			return entries[i].Order < entries[j].Order
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+// scanDocs 扫描 docDirs 中的 Markdown 文档，路由与仓库内路径一致，如 /docs/learning_plan.md
+func (c *Catalog) scanDocs() ([]Entry, error) {
+	var entries []Entry
+	for _, dir := range docDirs {
+		files, err := os.ReadDir(filepath.Join(c.root, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("扫描 %s 失败: %w", dir, err)
+		}
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".md") {
+				continue
+			}
+			p := path.Join(dir, f.Name())
+			entries = append(entries, Entry{
+				Kind:  KindDoc,
+				ID:    p,
+				Order: len(entries) + 1,
+				Title: markdownTitle(filepath.Join(c.root, p), p),
+				Path:  p,
+				Route: "/" + p,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// markdownTitle 返回 Markdown 文档的第一个一级标题
+func markdownTitle(filename, fallback string) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fallback
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return fallback
+}
+
+// describe 从文件开头注释提取标题和简介，
+// 没有开头注释时退回到 main 函数的文档注释，再退回到 fallback
+func describe(filename, fallback string) (title, summary string) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil && f == nil {
+		return fallback, ""
+	}
+
+	var lines []string
+	if text := leadingComment(f); text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if len(lines) == 0 {
+		return fallback, ""
+	}
+
+	title = strings.TrimSpace(lines[0])
+	var rest []string
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		// 练习模板中的日期行不作为简介
+		if line == "" || strings.HasPrefix(line, "日期") {
+			continue
+		}
+		rest = append(rest, line)
+	}
+	return title, strings.Join(rest, " ")
+}
+
+// leadingComment 返回 package 语句之前的注释，或 main 函数的文档注释
+func leadingComment(f *ast.File) string {
+	if len(f.Comments) > 0 && f.Comments[0].End() < f.Package {
+		return strings.TrimSpace(f.Comments[0].Text())
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" && fn.Doc != nil {
+			return strings.TrimSpace(fn.Doc.Text())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Module gobase 模块清单中的一项
+type Module struct {
+	ID            string   `json:"id"`
+	File          string   `json:"file"` // gobase 目录下的文件名
+	Title         string   `json:"title"`
+	Summary       string   `json:"summary"`
+	Tags          []string `json:"tags"`
+	Minutes       int      `json:"minutes"`       // 预计学习时长（分钟）
+	Prerequisites []string `json:"prerequisites"` // 前置模块 ID
+	ExerciseDays  []int    `json:"exercise_days"` // 对应 exercises/README.md 中的练习天数
+}
+
+// Manifest gobase/manifest.json，按学习顺序描述全部模块
+type Manifest struct {
+	Modules []Module `json:"modules"`
+}
+
+// LoadManifest 读取并解析模块清单
+func LoadManifest(filename string) (*Manifest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取模块清单失败: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("解析模块清单失败: %w", err)
+	}
+	return &m, nil
+}
+
+// Validate 校验清单：ID 唯一、文件存在、前置模块存在且无环，
+// 练习天数在计划内并且与计划中 "参考 gobase/xx.go" 的对应关系一致
+func (m *Manifest) Validate(gobaseDir string, plan []PlanDay) error {
+	var problems []string
+
+	planned := make(map[int]PlanDay, len(plan))
+	for _, d := range plan {
+		planned[d.Day] = d
+	}
+
+	ids := make(map[string]bool, len(m.Modules))
+	files := make(map[string]bool, len(m.Modules))
+	for _, mod := range m.Modules {
+		if mod.ID == "" {
+			problems = append(problems, fmt.Sprintf("模块 %q 缺少 id", mod.File))
+			continue
+		}
+		if ids[mod.ID] {
+			problems = append(problems, fmt.Sprintf("模块 id 重复: %s", mod.ID))
+		}
+		ids[mod.ID] = true
+
+		if files[mod.File] {
+			problems = append(problems, fmt.Sprintf("模块文件重复: %s", mod.File))
+		}
+		files[mod.File] = true
+		if _, err := os.Stat(filepath.Join(gobaseDir, mod.File)); err != nil {
+			problems = append(problems, fmt.Sprintf("模块 %s 引用的文件不存在: %s", mod.ID, mod.File))
+		}
+
+		for _, day := range mod.ExerciseDays {
+			d, ok := planned[day]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("模块 %s 引用的练习 Day %02d 不在练习计划中", mod.ID, day))
+			case d.Module != mod.File:
+				problems = append(problems, fmt.Sprintf("模块 %s 包含 Day %02d，但练习计划中该天参考的是 %q", mod.ID, day, d.Module))
+			}
+		}
+	}
+
+	for _, d := range plan {
+		if d.Module == "" {
+			continue
+		}
+		mod, ok := m.ModuleByFile(d.Module)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("练习计划 Day %02d 参考的 gobase/%s 不在模块清单中", d.Day, d.Module))
+			continue
+		}
+		if !containsDay(mod.ExerciseDays, d.Day) {
+			problems = append(problems, fmt.Sprintf("练习计划 Day %02d 参考 %s，但模块 %s 的 exercise_days 中没有该天", d.Day, d.Module, mod.ID))
+		}
+	}
+
+	for _, mod := range m.Modules {
+		for _, pre := range mod.Prerequisites {
+			if !ids[pre] {
+				problems = append(problems, fmt.Sprintf("模块 %s 的前置模块不存在: %s", mod.ID, pre))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		if _, err := m.Levels(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("模块清单校验失败:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Module 根据 ID 查找模块
+func (m *Manifest) Module(id string) (Module, bool) {
+	for _, mod := range m.Modules {
+		if mod.ID == id {
+			return mod, true
+		}
+	}
+	return Module{}, false
+}
+
+// ModuleByFile 根据 gobase 文件名查找模块
+func (m *Manifest) ModuleByFile(file string) (Module, bool) {
+	for _, mod := range m.Modules {
+		if mod.File == file {
+			return mod, true
+		}
+	}
+	return Module{}, false
+}
+
+// Neighbors 返回清单顺序中的上一个和下一个模块
+func (m *Manifest) Neighbors(id string) (prev, next *Module) {
+	for i := range m.Modules {
+		if m.Modules[i].ID != id {
+			continue
+		}
+		if i > 0 {
+			prev = &m.Modules[i-1]
+		}
+		if i+1 < len(m.Modules) {
+			next = &m.Modules[i+1]
+		}
+		break
+	}
+	return prev, next
+}
+
+// Dependents 返回以 id 为前置条件的模块
+func (m *Manifest) Dependents(id string) []Module {
+	var result []Module
+	for _, mod := range m.Modules {
+		for _, pre := range mod.Prerequisites {
+			if pre == id {
+				result = append(result, mod)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Levels 按前置关系对模块分层（拓扑排序），第 0 层没有前置模块；存在环时返回错误
+func (m *Manifest) Levels() ([][]Module, error) {
+	level := make(map[string]int, len(m.Modules))
+	var levels [][]Module
+
+	remaining := m.Modules
+	for len(remaining) > 0 {
+		var current, rest []Module
+		for _, mod := range remaining {
+			ready := true
+			for _, pre := range mod.Prerequisites {
+				if _, ok := level[pre]; !ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				current = append(current, mod)
+			} else {
+				rest = append(rest, mod)
+			}
+		}
+		if len(current) == 0 {
+			var ids []string
+			for _, mod := range rest {
+				ids = append(ids, mod.ID)
+			}
+			return nil, fmt.Errorf("模块前置关系存在环: %s", strings.Join(ids, ", "))
+		}
+		for _, mod := range current {
+			level[mod.ID] = len(levels)
+		}
+		levels = append(levels, current)
+		remaining = rest
+	}
+	return levels, nil
+}
+
+// containsDay 判断 days 中是否包含 day
+func containsDay(days []int, day int) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// levelIDs 把分层结果转换为 ID，便于比较
+func levelIDs(levels [][]Module) [][]string {
+	var ids [][]string
+	for _, level := range levels {
+		var row []string
+		for _, mod := range level {
+			row = append(row, mod.ID)
+		}
+		ids = append(ids, row)
+	}
+	return ids
+}
+
+func TestManifestLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []Module
+		want    [][]string
+		wantErr string
+	}{
+		{name: "empty", modules: nil, want: nil},
+		{
+			name:    "independent modules share level 0",
+			modules: []Module{{ID: "a"}, {ID: "b"}},
+			want:    [][]string{{"a", "b"}},
+		},
+		{
+			name: "chain and diamond",
+			modules: []Module{
+				{ID: "d", Prerequisites: []string{"b", "c"}},
+				{ID: "b", Prerequisites: []string{"a"}},
+				{ID: "c", Prerequisites: []string{"a"}},
+				{ID: "a"},
+			},
+			want: [][]string{{"a"}, {"b", "c"}, {"d"}},
+		},
+		{
+			name: "cycle",
+			modules: []Module{
+				{ID: "a"},
+				{ID: "b", Prerequisites: []string{"c"}},
+				{ID: "c", Prerequisites: []string{"b"}},
+			},
+			wantErr: "b, c",
+		},
+		{
+			name:    "missing prerequisite never becomes ready",
+			modules: []Module{{ID: "a", Prerequisites: []string{"x"}}},
+			wantErr: "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{Modules: tt.modules}
+			levels, err := m.Levels()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Levels() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Levels() = %v", err)
+			}
+			if got := levelIDs(levels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Levels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"01_a.go", "02_b.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plan := []PlanDay{{Day: 1, Module: "01_a.go"}, {Day: 2, Module: "02_b.go"}, {Day: 3}}
+
+	tests := []struct {
+		name    string
+		modules []Module
+		plan    []PlanDay
+		want    []string // 错误信息中应包含的片段，为空表示校验通过
+	}{
+		{
+			name: "valid",
+			modules: []Module{
+				{ID: "a", File: "01_a.go", ExerciseDays: []int{1}},
+				{ID: "b", File: "02_b.go", Prerequisites: []string{"a"}, ExerciseDays: []int{2}},
+			},
+			plan: plan,
+		},
+		{
+			name:    "missing id",
+			modules: []Module{{File: "01_a.go"}},
+			want:    []string{`模块 "01_a.go" 缺少 id`},
+		},
+		{
+			name:    "duplicate id and file",
+			modules: []Module{{ID: "a", File: "01_a.go"}, {ID: "a", File: "01_a.go"}},
+			want:    []string{"模块 id 重复: a", "模块文件重复: 01_a.go"},
+		},
+		{
+			name:    "missing file",
+			modules: []Module{{ID: "x", File: "99_x.go"}},
+			want:    []string{"模块 x 引用的文件不存在: 99_x.go"},
+		},
+		{
+			name:    "day not in plan",
+			modules: []Module{{ID: "a", File: "01_a.go", ExerciseDays: []int{9}}},
+			plan:    nil,
+			want:    []string{"模块 a 引用的练习 Day 09 不在练习计划中"},
+		},
+		{
+			name:    "day references another module",
+			modules: []Module{{ID: "a", File: "01_a.go", ExerciseDays: []int{1, 2}}},
+			plan:    plan[:2],
+			want:    []string{"模块 a 包含 Day 02", "练习计划 Day 02 参考的 gobase/02_b.go 不在模块清单中"},
+		},
+		{
+			name:    "plan day missing from exercise_days",
+			modules: []Module{{ID: "a", File: "01_a.go"}},
+			plan:    plan[:1],
+			want:    []string{"模块 a 的 exercise_days 中没有该天"},
+		},
+		{
+			name:    "unknown prerequisite",
+			modules: []Module{{ID: "a", File: "01_a.go", Prerequisites: []string{"z"}}},
+			want:    []string{"模块 a 的前置模块不存在: z"},
+		},
+		{
+			name: "cycle",
+			modules: []Module{
+				{ID: "a", File: "01_a.go", Prerequisites: []string{"b"}},
+				{ID: "b", File: "02_b.go", Prerequisites: []string{"a"}},
+			},
+			want: []string{"模块前置关系存在环"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{Modules: tt.modules}
+			err := m.Validate(dir, tt.plan)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want errors %q", tt.want)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("Validate() = %v, want containing %q", err, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	planWeekPattern = regexp.MustCompile(`^###\s+(第.+周)[：:]\s*(.+)$`)
+	planDayPattern  = regexp.MustCompile(`^-\s+\*\*Day\s+(\d+)\*\*[：:]\s*(.+)$`)
+	planRefPattern  = regexp.MustCompile("\\s*·\\s*参考\\s*`gobase/([^`]+)`\\s*$")
+)
+
+// PlanDay exercises/README.md 练习计划中的一天
+type PlanDay struct {
+	Day       int    `json:"day"`
+	Week      int    `json:"week"`
+	WeekTitle string `json:"week_title"`
+	Topic     string `json:"topic"`
+	Module    string `json:"module,omitempty"` // 行尾 "· 参考 `gobase/xx.go`" 中的 gobase 文件名
+}
+
+// Group 返回该天对应的练习目录名，如 day03
+func (d PlanDay) Group() string {
+	return fmt.Sprintf("day%02d", d.Day)
+}
+
+// ParsePlan 解析 exercises/README.md 中 "### 第一周：..." 和 "- **Day 01**: ..." 形式的练习计划，
+// 行尾可选的 "· 参考 `gobase/xx.go`" 表示当天对应的基础模块
+func ParsePlan(filename string) ([]PlanDay, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取练习计划失败: %w", err)
+	}
+	defer f.Close()
+	return parsePlan(f)
+}
+
+// parsePlan 从 r 中解析练习计划，格式见 ParsePlan
+func parsePlan(r io.Reader) ([]PlanDay, error) {
+	var (
+		days      []PlanDay
+		week      int
+		weekTitle string
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := planWeekPattern.FindStringSubmatch(line); m != nil {
+			week++
+			weekTitle = m[1] + "：" + m[2]
+			continue
+		}
+		if m := planDayPattern.FindStringSubmatch(line); m != nil && week > 0 {
+			day, _ := strconv.Atoi(m[1])
+			d := PlanDay{
+				Day:       day,
+				Week:      week,
+				WeekTitle: weekTitle,
+				Topic:     strings.TrimSpace(m[2]),
+			}
+			if ref := planRefPattern.FindStringSubmatch(d.Topic); ref != nil {
+				d.Module = ref[1]
+				d.Topic = strings.TrimSpace(strings.TrimSuffix(d.Topic, ref[0]))
+			}
+			days = append(days, d)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取练习计划失败: %w", err)
+	}
+	return days, nil
+}
+
+// PlanDayByNumber 在练习计划中查找第 day 天
+func PlanDayByNumber(plan []PlanDay, day int) (PlanDay, bool) {
+	for _, d := range plan {
+		if d.Day == day {
+			return d, true
+		}
+	}
+	return PlanDay{}, false
+}
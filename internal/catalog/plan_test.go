@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []PlanDay
+	}{
+		{
+			name: "weeks and days",
+			text: "# 练习\n\n### 第一周：基础\n- **Day 01**: Hello World\n- **Day 02**：变量\n\n### 第二周: 进阶\n- **Day 08**: 并发\n",
+			want: []PlanDay{
+				{Day: 1, Week: 1, WeekTitle: "第一周：基础", Topic: "Hello World"},
+				{Day: 2, Week: 1, WeekTitle: "第一周：基础", Topic: "变量"},
+				{Day: 8, Week: 2, WeekTitle: "第二周：进阶", Topic: "并发"},
+			},
+		},
+		{
+			name: "module reference",
+			text: "### 第一周：基础\n- **Day 03**: 切片 CRUD · 参考 `gobase/02_slices.go`\n",
+			want: []PlanDay{{Day: 3, Week: 1, WeekTitle: "第一周：基础", Topic: "切片 CRUD", Module: "02_slices.go"}},
+		},
+		{
+			name: "days before the first week are ignored",
+			text: "- **Day 01**: 没有周标题\n### 第一周：基础\n- **Day 02**: 变量\n",
+			want: []PlanDay{{Day: 2, Week: 1, WeekTitle: "第一周：基础", Topic: "变量"}},
+		},
+		{
+			name: "other list items are ignored",
+			text: "### 第一周：基础\n- Day 01: 不是加粗\n- **Day 02**: 变量\n  - **Day 03** 缺少冒号\n",
+			want: []PlanDay{{Day: 2, Week: 1, WeekTitle: "第一周：基础", Topic: "变量"}},
+		},
+		{name: "empty", text: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlan(strings.NewReader(tt.text))
+			if err != nil {
+				t.Fatalf("parsePlan() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanDayByNumber(t *testing.T) {
+	plan := []PlanDay{{Day: 1, Topic: "a"}, {Day: 3, Topic: "c"}}
+	tests := []struct {
+		day    int
+		want   string
+		wantOK bool
+	}{
+		{1, "a", true},
+		{3, "c", true},
+		{2, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := PlanDayByNumber(plan, tt.day)
+		if ok != tt.wantOK || got.Topic != tt.want {
+			t.Errorf("PlanDayByNumber(%d) = %q, %v, want %q, %v", tt.day, got.Topic, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package compare
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/runner"
+)
+
+// Verdict 对比结论
+type Verdict string
+
+const (
+	VerdictPass    Verdict = "pass"    // 归一化后输出完全一致
+	VerdictPartial Verdict = "partial" // 部分行一致
+	VerdictFail    Verdict = "fail"    // 练习无法运行或几乎没有相同的行
+)
+
+// Resolve 的错误：练习计划、练习或参考模块不存在，接口返回 404
+var (
+	ErrNoPlanDay   = apperr.New(apperr.NotFound, "plan_day_not_found", "compare.no_plan_day")
+	ErrNoModule    = apperr.New(apperr.NotFound, "plan_module_not_found", "compare.no_module")
+	ErrNoExercise  = apperr.New(apperr.NotFound, "exercise_not_found", "compare.no_exercise")
+	ErrNoReference = apperr.New(apperr.NotFound, "reference_not_found", "compare.no_reference")
+)
+
+// partialThreshold 相似度达到该值时判定为 partial
+const partialThreshold = 0.3
+
+// maxDiffLines 参与逐行对比的最大行数，避免超长输出导致 LCS 计算过慢
+const maxDiffLines = 2000
+
+var (
+	pointerPattern   = regexp.MustCompile(`0x[0-9a-fA-F]{6,}`)
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?( [+-]\d{4} \w+)?( m=[+-]\d+\.\d+)?`)
+	durationPattern  = regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|us|ms|s)\b`)
+	pairPattern      = regexp.MustCompile(`^\(?[^\s:()]+:[^\s()]+\)?$`)
+)
+
+// Normalize 把程序输出按行归一化，消除不确定的部分：
+// 指针地址替换为 0xADDR，时间戳替换为 <TIME>，耗时替换为 <DURATION>，
+// 同一行中多个 key:value 形式的片段（遍历 map 的输出）按字典序重排。
+func Normalize(output string) []string {
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return nil
+	}
+
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		line = pointerPattern.ReplaceAllString(line, "0xADDR")
+		line = timestampPattern.ReplaceAllString(line, "<TIME>")
+		line = durationPattern.ReplaceAllString(line, "<DURATION>")
+		lines[i] = sortPairs(line)
+	}
+	return lines
+}
+
+// sortPairs 对一行中两个及以上的 key:value 片段排序，其余片段位置不变；
+// fmt 打印的 map[...] 已按键排序，方括号内的片段不参与重排
+func sortPairs(line string) string {
+	fields := strings.Fields(line)
+	var idx []int
+	var pairs []string
+	depth := 0
+	for i, f := range fields {
+		if depth == 0 && !strings.ContainsAny(f, "[]") && pairPattern.MatchString(f) {
+			idx = append(idx, i)
+			pairs = append(pairs, f)
+		}
+		depth += strings.Count(f, "[") - strings.Count(f, "]")
+	}
+	if len(pairs) < 2 {
+		return line
+	}
+	sort.Strings(pairs)
+	for i, j := range idx {
+		fields[j] = pairs[i]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Op 对比行的类型
+type Op string
+
+const (
+	OpEqual  Op = "equal"  // 两边相同
+	OpDelete Op = "delete" // 只出现在 gobase 参考输出中
+	OpInsert Op = "insert" // 只出现在练习输出中
+)
+
+// Line 逐行对比结果中的一行
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// Diff 基于最长公共子序列计算逐行差异，expected 为参考输出，actual 为练习输出
+func Diff(expected, actual []string) []Line {
+	if len(expected) > maxDiffLines {
+		expected = expected[:maxDiffLines]
+	}
+	if len(actual) > maxDiffLines {
+		actual = actual[:maxDiffLines]
+	}
+
+	n, m := len(expected), len(actual)
+	// lcs[i][j] 为 expected[i:] 与 actual[j:] 的最长公共子序列长度
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case expected[i] == actual[j]:
+			lines = append(lines, Line{Op: OpEqual, Text: expected[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: OpDelete, Text: expected[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: OpInsert, Text: actual[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		lines = append(lines, Line{Op: OpDelete, Text: expected[i]})
+	}
+	for ; j < m; j++ {
+		lines = append(lines, Line{Op: OpInsert, Text: actual[j]})
+	}
+	return lines
+}
+
+// Similarity 返回相同行占两边总行数的比例（0~1）
+func Similarity(lines []Line) float64 {
+	var equal, total int
+	for _, l := range lines {
+		if l.Op == OpEqual {
+			equal += 2
+			total += 2
+		} else {
+			total++
+		}
+	}
+	if total == 0 {
+		return 1
+	}
+	return float64(equal) / float64(total)
+}
+
+// Output 一次运行的输出
+type Output struct {
+	Path   string        `json:"path"`
+	Result runner.Result `json:"result"`
+	Stdout string        `json:"stdout"`
+	Stderr string        `json:"stderr"`
+}
+
+// Report 练习与 gobase 参考模块的对比报告
+type Report struct {
+	Day        int     `json:"day"`
+	Topic      string  `json:"topic"`
+	Exercise   Output  `json:"exercise"`
+	Reference  Output  `json:"reference"`
+	Diff       []Line  `json:"diff"`
+	Similarity float64 `json:"similarity"`
+	Verdict    Verdict `json:"verdict"`
+	Message    string  `json:"message"`
+}
+
+// Resolve 根据 exercises/README.md 练习计划中的 "参考 gobase/xx.go" 找到第 day 天的练习文件和参考模块
+func Resolve(cat *catalog.Catalog, day int) (plan catalog.PlanDay, exercise, reference catalog.Entry, err error) {
+	plan, ok := catalog.PlanDayByNumber(cat.Plan(), day)
+	if !ok {
+		return plan, exercise, reference, ErrNoPlanDay.With(day)
+	}
+	if plan.Module == "" {
+		return plan, exercise, reference, ErrNoModule.With(day)
+	}
+
+	files := cat.Day(plan.Group())
+	if len(files) == 0 {
+		return plan, exercise, reference, ErrNoExercise.With(plan.Group())
+	}
+	reference, ok = cat.LookupPath("gobase/" + plan.Module)
+	if !ok {
+		return plan, exercise, reference, ErrNoReference.With(plan.Module)
+	}
+	return plan, files[0], reference, nil
+}
+
+// Run 依次运行第 day 天的练习和参考模块，对比归一化后的标准输出
+func Run(ctx context.Context, run *runner.Runner, cat *catalog.Catalog, day int) (*Report, error) {
+	plan, exercise, reference, err := Resolve(cat, day)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Day: day, Topic: plan.Topic}
+	report.Exercise, err = execute(ctx, run, cat.Root(), exercise.Path)
+	if err != nil {
+		return nil, err
+	}
+	report.Reference, err = execute(ctx, run, cat.Root(), reference.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Diff = Diff(Normalize(report.Reference.Stdout), Normalize(report.Exercise.Stdout))
+	report.Similarity = Similarity(report.Diff)
+
+	// 结论按 context 中的请求语言翻译
+	locale := i18n.FromContext(ctx)
+	switch {
+	case !report.Exercise.Result.OK():
+		report.Verdict = VerdictFail
+		report.Message = i18n.T(locale, "compare.exercise_failed")
+	case !report.Reference.Result.OK():
+		// 参考模块本身跑不起来时只能给出部分参考
+		report.Verdict = VerdictPartial
+		report.Message = i18n.T(locale, "compare.reference_failed")
+	case report.Similarity == 1:
+		report.Verdict = VerdictPass
+		report.Message = i18n.T(locale, "compare.identical")
+	case report.Similarity >= partialThreshold:
+		report.Verdict = VerdictPartial
+		report.Message = i18n.T(locale, "compare.partial", report.Similarity*100)
+	default:
+		report.Verdict = VerdictFail
+		report.Message = i18n.T(locale, "compare.different")
+	}
+	return report, nil
+}
+
+// execute 运行单个文件并收集输出
+func execute(ctx context.Context, run *runner.Runner, root, path string) (Output, error) {
+	var stdout, stderr strings.Builder
+	result, err := run.Run(ctx, filepath.Join(root, path), func(ev runner.Event) {
+		switch ev.Stream {
+		case runner.StreamStdout:
+			stdout.WriteString(ev.Data)
+		case runner.StreamStderr:
+			stderr.WriteString(ev.Data)
+		}
+	})
+	if err != nil {
+		return Output{}, err
+	}
+	return Output{Path: path, Result: result, Stdout: stdout.String(), Stderr: stderr.String()}, nil
+}
@@ -0,0 +1,104 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{name: "empty", output: "", want: nil},
+		{name: "only newlines", output: "\n\n", want: nil},
+		{name: "crlf and trailing spaces", output: "a  \r\nb\t\r\n", want: []string{"a", "b"}},
+		{name: "blank lines inside are kept", output: "a\n\nb\n", want: []string{"a", "", "b"}},
+		{name: "pointer", output: "p=0xc000012345 &{1}", want: []string{"p=0xADDR &{1}"}},
+		{name: "short hex is kept", output: "mask 0xff", want: []string{"mask 0xff"}},
+		{
+			name:   "time.Now output",
+			output: "now: 2024-05-01 10:20:30.123456 +0800 CST m=+0.000123",
+			want:   []string{"now: <TIME>"},
+		},
+		{name: "rfc3339", output: "at 2024-05-01T10:20:30Z done", want: []string{"at <TIME> done"}},
+		{name: "durations", output: "took 1.5ms, then 20µs and 3s", want: []string{"took <DURATION>, then <DURATION> and <DURATION>"}},
+		{name: "key:value pairs are sorted", output: "b:2 a:1 c:3", want: []string{"a:1 b:2 c:3"}},
+		{name: "other fields keep their position", output: "map b:2 x a:1", want: []string{"map a:1 x b:2"}},
+		{name: "single pair is kept", output: "key:value", want: []string{"key:value"}},
+		{name: "map print is not reordered", output: "map[b:2 a:1]", want: []string{"map[b:2 a:1]"}},
+		{name: "pairs after brackets are sorted", output: "[x y] d:4 c:3", want: []string{"[x y] c:3 d:4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   []string
+		actual     []string
+		want       []Line
+		similarity float64
+	}{
+		{name: "both empty", want: nil, similarity: 1},
+		{
+			name:       "identical",
+			expected:   []string{"a", "b"},
+			actual:     []string{"a", "b"},
+			want:       []Line{{OpEqual, "a"}, {OpEqual, "b"}},
+			similarity: 1,
+		},
+		{
+			name:       "only expected",
+			expected:   []string{"a"},
+			want:       []Line{{OpDelete, "a"}},
+			similarity: 0,
+		},
+		{
+			name:       "only actual",
+			actual:     []string{"a"},
+			want:       []Line{{OpInsert, "a"}},
+			similarity: 0,
+		},
+		{
+			name:       "changed line",
+			expected:   []string{"a", "b", "c"},
+			actual:     []string{"a", "x", "c"},
+			want:       []Line{{OpEqual, "a"}, {OpDelete, "b"}, {OpInsert, "x"}, {OpEqual, "c"}},
+			similarity: 4.0 / 6,
+		},
+		{
+			name:       "inserted and removed lines",
+			expected:   []string{"a", "b", "c"},
+			actual:     []string{"x", "a", "c"},
+			want:       []Line{{OpInsert, "x"}, {OpEqual, "a"}, {OpDelete, "b"}, {OpEqual, "c"}},
+			similarity: 4.0 / 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.expected, tt.actual)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+			if s := Similarity(got); s != tt.similarity {
+				t.Errorf("Similarity() = %v, want %v", s, tt.similarity)
+			}
+		})
+	}
+}
+
+func TestDiffTruncatesLongOutput(t *testing.T) {
+	long := make([]string, maxDiffLines+10)
+	got := Diff(long, long)
+	if len(got) != maxDiffLines {
+		t.Errorf("len(Diff()) = %d, want %d", len(got), maxDiffLines)
+	}
+}
@@ -0,0 +1,258 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/fsutil"
+)
+
+// MaxFileSize 允许保存的最大文件大小
+const MaxFileSize = 256 << 10
+
+// BackupDir 旧版本备份所在目录，相对仓库根目录
+const BackupDir = ".study/backups"
+
+// 编辑器错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrOutsideExercises 路径不在 exercises/ 下，或不是 .go 文件
+	ErrOutsideExercises = apperr.New(apperr.Forbidden, "outside_exercises", "editor.outside_exercises")
+	// ErrTooLarge 文件超过 MaxFileSize，接口返回 413
+	ErrTooLarge = apperr.New(apperr.Validation, "file_too_large", "editor.too_large", MaxFileSize>>10)
+	// ErrNotFound 要编辑的文件不存在
+	ErrNotFound = apperr.New(apperr.NotFound, "file_not_found", "file.not_found")
+)
+
+// Severity 诊断级别
+type Severity string
+
+// SeverityError 会导致编译失败的错误；go/types 报告的未使用导入和变量同样会让编译失败
+const SeverityError Severity = "error"
+
+// Diagnostic 源码中的一条诊断信息
+type Diagnostic struct {
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Source   string   `json:"source"` // parser、types 或 format
+	Message  string   `json:"message"`
+}
+
+// SaveResult 保存结果
+type SaveResult struct {
+	Path        string       `json:"path"`
+	Content     string       `json:"content"`          // 实际写入的内容（可能已格式化）
+	Formatted   bool         `json:"formatted"`        // 内容是否经过 go/format
+	Backup      string       `json:"backup,omitempty"` // 旧版本的备份路径
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Editor exercises/ 下练习文件的编辑器
+type Editor struct {
+	root string
+
+	mu       sync.Mutex // 串行化保存，保证备份和写入的顺序
+	typesMu  sync.Mutex // go/types 的导入器不是并发安全的
+	importer types.Importer
+}
+
+// New 创建编辑器，root 为仓库根目录
+func New(root string) *Editor {
+	return &Editor{root: root, importer: importer.Default()}
+}
+
+// Resolve 把请求中的相对路径规范化为 exercises/ 下的 .go 文件，
+// 返回磁盘路径和使用 / 的相对路径；符号链接解析后仍需位于 exercises/ 中
+func (e *Editor) Resolve(p string) (string, string, error) {
+	p = filepath.FromSlash(strings.TrimPrefix(p, "/"))
+	if p == "" || filepath.IsAbs(p) || filepath.VolumeName(p) != "" || filepath.Ext(p) != ".go" {
+		return "", "", ErrOutsideExercises
+	}
+
+	root, err := filepath.Abs(e.root)
+	if err != nil {
+		return "", "", err
+	}
+	if r, err := filepath.EvalSymlinks(root); err == nil {
+		root = r
+	}
+	base := filepath.Join(root, "exercises")
+	full := filepath.Join(root, filepath.Clean(p))
+	if !within(base, full) {
+		return "", "", ErrOutsideExercises
+	}
+
+	// 文件或其所在目录可能是指向 exercises/ 以外的符号链接
+	resolved := full
+	if r, err := filepath.EvalSymlinks(full); err == nil {
+		resolved = r
+	} else if r, err := filepath.EvalSymlinks(filepath.Dir(full)); err == nil {
+		resolved = filepath.Join(r, filepath.Base(full))
+	} else {
+		return "", "", ErrOutsideExercises.Wrap(err)
+	}
+	if !within(base, resolved) {
+		return "", "", ErrOutsideExercises
+	}
+
+	rel, err := filepath.Rel(root, full)
+	if err != nil {
+		return "", "", ErrOutsideExercises
+	}
+	return resolved, filepath.ToSlash(rel), nil
+}
+
+// within 判断 target 是否位于 dir 之下
+func within(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// Load 读取练习文件并返回诊断信息
+func (e *Editor) Load(p string) (*SaveResult, error) {
+	filename, rel, err := e.Resolve(p)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound.Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SaveResult{Path: rel, Content: string(content), Diagnostics: e.Check(rel, content)}, nil
+}
+
+// Format 使用 go/format 格式化源码，有语法错误时返回原内容和诊断信息
+func Format(src []byte) ([]byte, []Diagnostic) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return src, errorDiagnostics(err, "format")
+	}
+	return formatted, nil
+}
+
+// Check 使用 go/parser 和 go/types 检查源码：语法错误、未定义的名字、未使用的导入和变量、类型不匹配等
+func (e *Editor) Check(filename string, src []byte) []Diagnostic {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Base(filename), src, parser.AllErrors|parser.ParseComments)
+	if err != nil {
+		return errorDiagnostics(err, "parser")
+	}
+
+	diags := []Diagnostic{}
+	conf := types.Config{
+		Importer: e.importer,
+		Error: func(err error) {
+			te, ok := err.(types.Error)
+			if !ok {
+				diags = append(diags, Diagnostic{Line: 1, Column: 1, Severity: SeverityError, Source: "types", Message: err.Error()})
+				return
+			}
+			pos := te.Fset.Position(te.Pos)
+			diags = append(diags, Diagnostic{Line: pos.Line, Column: pos.Column, Severity: SeverityError, Source: "types", Message: te.Msg})
+		},
+	}
+	e.typesMu.Lock()
+	conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	e.typesMu.Unlock()
+
+	sortDiagnostics(diags)
+	return diags
+}
+
+// Save 保存练习文件：可选先格式化，旧版本复制到 .study/backups/，再原子写入
+func (e *Editor) Save(p string, content []byte, formatSource bool) (*SaveResult, error) {
+	if len(content) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	filename, rel, err := e.Resolve(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SaveResult{Path: rel}
+	if formatSource {
+		// 有语法错误时按原样保存，错误在诊断信息中返回
+		formatted, diags := Format(content)
+		result.Formatted = diags == nil
+		content = formatted
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	perm := os.FileMode(0o644)
+	if old, err := os.ReadFile(filename); err == nil {
+		if info, err := os.Stat(filename); err == nil {
+			perm = info.Mode().Perm()
+		}
+		backup, err := e.backup(rel, old)
+		if err != nil {
+			return nil, fmt.Errorf("备份旧版本失败: %w", err)
+		}
+		result.Backup = backup
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := fsutil.WriteFileAtomic(filename, content, perm); err != nil {
+		return nil, err
+	}
+
+	result.Content = string(content)
+	result.Diagnostics = e.Check(rel, content)
+	return result, nil
+}
+
+// backup 把旧版本写入 .study/backups/<相对路径>.<时间戳>，返回备份的相对路径
+func (e *Editor) backup(rel string, content []byte) (string, error) {
+	name := rel + "." + time.Now().Format("20060102-150405.000")
+	target := filepath.Join(e.root, filepath.FromSlash(BackupDir), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+	if err := fsutil.WriteFileAtomic(target, content, 0o644); err != nil {
+		return "", err
+	}
+	return BackupDir + "/" + name, nil
+}
+
+// errorDiagnostics 把 go/scanner 的错误列表转换为诊断信息
+func errorDiagnostics(err error, source string) []Diagnostic {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityError, Source: source, Message: err.Error()}}
+	}
+	diags := make([]Diagnostic, 0, len(list))
+	for _, e := range list {
+		diags = append(diags, Diagnostic{Line: e.Pos.Line, Column: e.Pos.Column, Severity: SeverityError, Source: source, Message: e.Msg})
+	}
+	sortDiagnostics(diags)
+	return diags
+}
+
+// sortDiagnostics 按行列排序
+func sortDiagnostics(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+}
@@ -0,0 +1,98 @@
+package editor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// 编辑请求的动作
+const (
+	ActionCheck  = "check"  // 只检查，不写入
+	ActionFormat = "format" // 格式化并检查，不写入
+	ActionSave   = "save"   // 写入磁盘
+)
+
+// EditRequest 编辑接口的请求
+type EditRequest struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Action  string `json:"action"` // check、format 或 save，默认 save
+	Format  bool   `json:"format"` // 保存前是否格式化
+}
+
+// APIHandler /api/v1/editor：GET ?path= 读取文件和诊断信息，POST EditRequest 检查、格式化或保存
+func (e *Editor) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			result, err := e.Load(r.URL.Query().Get("path"))
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, result)
+
+		case http.MethodPost:
+			var req EditRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxFileSize*2)).Decode(&req); err != nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			_, rel, err := e.Resolve(req.Path)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			content := []byte(req.Content)
+
+			switch req.Action {
+			case ActionCheck:
+				handler.SuccessResponse(w, &SaveResult{Path: rel, Content: req.Content, Diagnostics: e.Check(rel, content)})
+			case ActionFormat:
+				formatted, diags := Format(content)
+				ok := diags == nil
+				if ok {
+					diags = e.Check(rel, formatted)
+				}
+				handler.SuccessResponse(w, &SaveResult{Path: rel, Content: string(formatted), Formatted: ok, Diagnostics: diags})
+			case ActionSave, "":
+				result, err := e.Save(rel, content, req.Format)
+				if err != nil {
+					writeError(w, r, err)
+					return
+				}
+				handler.SuccessResponse(w, result)
+			default:
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.unknown_action", req.Action)
+			}
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// writeError 输出编辑器错误：文件过大为 413，其余按 apperr 类别映射状态码
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrTooLarge) {
+		handler.ErrorResponseFrom(w, r, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	handler.WriteError(w, r, err)
+}
+
+// PageHandler /edit?path=exercises/day03/variables_practice.go 编辑页面
+func (e *Editor) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := e.Load(r.URL.Query().Get("path"))
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+		pages.Render(w, r, "editor", result)
+	}
+}
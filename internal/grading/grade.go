@@ -0,0 +1,470 @@
+package grading
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/runner"
+)
+
+// CheckKind 检查项类别
+type CheckKind string
+
+const (
+	KindFunction CheckKind = "function" // 函数存在且签名一致
+	KindType     CheckKind = "type"     // 类型存在且底层类型一致
+	KindCase     CheckKind = "case"     // 表驱动用例
+	KindStdout   CheckKind = "stdout"   // 标准输出片段
+)
+
+// caseMarker 测试程序输出用例结果时使用的行标记
+const caseMarker = "@@study-grade@@"
+
+// Check 一个检查项的结果
+type Check struct {
+	Kind     CheckKind `json:"kind"`
+	Name     string    `json:"name"`
+	Passed   bool      `json:"passed"`
+	Expected string    `json:"expected,omitempty"`
+	Actual   string    `json:"actual,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// Report 评分报告
+type Report struct {
+	Day          int     `json:"day"`
+	Topic        string  `json:"topic"`
+	Path         string  `json:"path"`
+	Checks       []Check `json:"checks"`
+	Passed       int     `json:"passed"`
+	Total        int     `json:"total"`
+	Score        float64 `json:"score"`                   // 0~100
+	CompileError string  `json:"compile_error,omitempty"` // 编译失败时的错误输出
+}
+
+// Grader 按评分规格检查练习：静态检查函数签名和类型，运行用例和程序本身检查输出
+type Grader struct {
+	cat *catalog.Catalog
+	run *runner.Runner
+
+	mu       sync.Mutex // go/types 的导入器不是并发安全的
+	importer types.Importer
+}
+
+// NewGrader 创建评分器
+func NewGrader(cat *catalog.Catalog, run *runner.Runner) *Grader {
+	return &Grader{cat: cat, run: run, importer: importer.Default()}
+}
+
+// Grade 为第 day 天的练习评分
+func (g *Grader) Grade(ctx context.Context, day int) (*Report, error) {
+	spec, err := LoadSpec(g.cat.Root(), day)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Day: day, Checks: []Check{}}
+	if plan, ok := catalog.PlanDayByNumber(g.cat.Plan(), day); ok {
+		report.Topic = plan.Topic
+	}
+
+	entry, err := g.pickFile(spec)
+	if err != nil {
+		return nil, err
+	}
+	report.Path = entry.Path
+	src, err := os.ReadFile(filepath.Join(g.cat.Root(), filepath.FromSlash(entry.Path)))
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.FromContext(ctx)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Base(entry.Path), src, parser.ParseComments)
+	if err != nil {
+		report.CompileError = err.Error()
+		for _, c := range allChecks(spec) {
+			c.Message = i18n.T(locale, "grading.syntax_error")
+			report.add(c)
+		}
+		return report.finish(), nil
+	}
+
+	available := g.staticChecks(locale, report, spec, fset, file)
+
+	if len(spec.Cases) > 0 {
+		if err := g.caseChecks(ctx, report, spec, entry.Path, src, fset, file, available); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.Stdout) > 0 {
+		if err := g.stdoutChecks(ctx, report, spec, entry.Path, src); err != nil {
+			return nil, err
+		}
+	}
+	return report.finish(), nil
+}
+
+// pickFile 选择要评分的文件：规格中指定的文件，或定义了第一个期望函数的文件，否则为当天的第一个文件
+func (g *Grader) pickFile(spec *Spec) (catalog.Entry, error) {
+	group := fmt.Sprintf("day%02d", spec.Day)
+	files := g.cat.Day(group)
+	if len(files) == 0 {
+		return catalog.Entry{}, ErrNoExercise.With(group)
+	}
+	if spec.File != "" {
+		for _, f := range files {
+			if filepath.Base(f.Path) == spec.File {
+				return f, nil
+			}
+		}
+		return catalog.Entry{}, ErrNoFile.With(group, spec.File)
+	}
+	if len(spec.Functions) > 0 {
+		for _, f := range files {
+			file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(g.cat.Root(), filepath.FromSlash(f.Path)), nil, parser.SkipObjectResolution)
+			if err == nil && declares(file, spec.Functions[0].Name) {
+				return f, nil
+			}
+		}
+	}
+	return files[0], nil
+}
+
+// staticChecks 类型检查源码，比较期望的函数签名和类型，返回检查通过的名字
+func (g *Grader) staticChecks(locale i18n.Locale, report *Report, spec *Spec, fset *token.FileSet, file *ast.File) map[string]bool {
+	conf := types.Config{Importer: g.importer, Error: func(error) {}}
+	g.mu.Lock()
+	pkg, _ := conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	g.mu.Unlock()
+	qualifier := types.RelativeTo(pkg)
+
+	available := make(map[string]bool)
+	for _, f := range spec.Functions {
+		c := Check{Kind: KindFunction, Name: f.Name, Expected: f.Signature}
+		fn, ok := pkg.Scope().Lookup(f.Name).(*types.Func)
+		if !ok {
+			c.Message = i18n.T(locale, "grading.no_function", f.Name)
+		} else {
+			c.Actual = signature(fn.Type().(*types.Signature), qualifier)
+			c.Passed = compact(c.Actual) == compact(f.Signature)
+			if !c.Passed {
+				c.Message = i18n.T(locale, "grading.signature_mismatch")
+			}
+		}
+		available[f.Name] = c.Passed
+		report.add(c)
+	}
+	for _, t := range spec.Types {
+		c := Check{Kind: KindType, Name: t.Name, Expected: t.Underlying}
+		tn, ok := pkg.Scope().Lookup(t.Name).(*types.TypeName)
+		if !ok {
+			c.Message = i18n.T(locale, "grading.no_type", t.Name)
+		} else {
+			c.Actual = types.TypeString(tn.Type().Underlying(), qualifier)
+			c.Passed = compact(c.Actual) == compact(t.Underlying)
+			if !c.Passed {
+				c.Message = i18n.T(locale, "grading.type_mismatch")
+			}
+		}
+		available[t.Name] = c.Passed
+		report.add(c)
+	}
+	return available
+}
+
+// stdoutChecks 运行练习，检查标准输出中是否包含期望的片段
+func (g *Grader) stdoutChecks(ctx context.Context, report *Report, spec *Spec, path string, src []byte) error {
+	stdout, stderr, result, err := g.execute(ctx, path, src)
+	if err != nil {
+		return err
+	}
+	locale := i18n.FromContext(ctx)
+	message := ""
+	switch {
+	case result.Stage == "build" && result.ExitCode != 0:
+		report.CompileError = stderr
+		message = i18n.T(locale, "grading.build_failed")
+	case !result.OK():
+		message = i18n.T(locale, "grading.run_failed")
+	}
+	for _, f := range spec.Stdout {
+		c := Check{Kind: KindStdout, Name: f.Name, Expected: f.Contains, Passed: strings.Contains(stdout, f.Contains)}
+		if !c.Passed {
+			c.Message = message
+			if c.Message == "" {
+				c.Message = i18n.T(locale, "grading.stdout_missing")
+			}
+		}
+		report.add(c)
+	}
+	return nil
+}
+
+// caseChecks 生成调用用例表达式的测试程序并运行，逐个比较结果
+func (g *Grader) caseChecks(ctx context.Context, report *Report, spec *Spec, path string, src []byte, fset *token.FileSet, file *ast.File, available map[string]bool) error {
+	locale := i18n.FromContext(ctx)
+	checks := make([]Check, len(spec.Cases))
+	var runnable []int
+	for i, tc := range spec.Cases {
+		checks[i] = Check{Kind: KindCase, Name: tc.Name, Expected: tc.Want}
+		if tc.Panics {
+			checks[i].Expected = "panic " + tc.Want
+		}
+		missing := ""
+		for _, n := range tc.Needs {
+			if !available[n] {
+				missing = n
+				break
+			}
+		}
+		if missing != "" {
+			checks[i].Message = i18n.T(locale, "grading.case_skipped", missing)
+			continue
+		}
+		runnable = append(runnable, i)
+	}
+
+	if len(runnable) > 0 {
+		program := harness(src, fset, file, spec, runnable)
+		stdout, stderr, result, err := g.execute(ctx, path, program)
+		if err != nil {
+			return err
+		}
+		outcomes := parseOutcomes(stdout)
+		for _, i := range runnable {
+			tc, c := spec.Cases[i], &checks[i]
+			o, ok := outcomes[i]
+			switch {
+			case result.Stage == "build" && result.ExitCode != 0:
+				c.Message = i18n.T(locale, "grading.case_build_failed")
+				if report.CompileError == "" {
+					report.CompileError = stderr
+				}
+			case !ok:
+				c.Message = i18n.T(locale, "grading.case_unfinished")
+			case o.panicked && !tc.Panics:
+				c.Actual = "panic " + o.value
+				c.Message = i18n.T(locale, "grading.case_panicked")
+			case tc.Panics:
+				c.Actual = o.value
+				if o.panicked {
+					c.Actual = "panic " + o.value
+				}
+				c.Passed = o.panicked && (tc.Want == "" || o.value == tc.Want)
+			default:
+				c.Actual = o.value
+				c.Passed = o.value == tc.Want
+			}
+		}
+	}
+	for _, c := range checks {
+		report.add(c)
+	}
+	return nil
+}
+
+// execute 运行源码并收集输出
+func (g *Grader) execute(ctx context.Context, path string, src []byte) (stdout, stderr string, result runner.Result, err error) {
+	var out, errOut strings.Builder
+	result, err = g.run.RunSource(ctx, path, src, func(ev runner.Event) {
+		switch ev.Stream {
+		case runner.StreamStdout:
+			out.WriteString(ev.Data)
+		case runner.StreamStderr:
+			errOut.WriteString(ev.Data)
+		}
+	})
+	return out.String(), errOut.String(), result, err
+}
+
+// harness 生成测试程序：把练习的 main 改名，在 package 子句的同一行追加导入（保持行号不变），
+// 在文件末尾加入逐个求值用例并打印结果的 main
+func harness(src []byte, fset *token.FileSet, file *ast.File, spec *Spec, cases []int) []byte {
+	imported := make(map[string]bool)
+	for _, is := range file.Imports {
+		path, _ := strconv.Unquote(is.Path.Value)
+		imported[path] = true
+	}
+	imports := `; import studyfmt "fmt"`
+	for _, path := range spec.Imports {
+		if !imported[path] {
+			imports += fmt.Sprintf("; import %q", path)
+		}
+	}
+
+	type edit struct {
+		offset int
+		remove int
+		text   string
+	}
+	edits := []edit{{offset: fset.Position(file.Name.End()).Offset, text: imports}}
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			edits = append(edits, edit{offset: fset.Position(fn.Name.Pos()).Offset, remove: len("main"), text: "studyOriginalMain"})
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	// 编辑按偏移从小到大应用：package 子句总在 main 之前
+	for _, e := range edits {
+		b.Write(src[last:e.offset])
+		b.WriteString(e.text)
+		last = e.offset + e.remove
+	}
+	b.Write(src[last:])
+
+	b.WriteString("\n\nfunc main() {\n")
+	for _, i := range cases {
+		fmt.Fprintf(&b, "\tstudyCase(%d, func() interface{} { return %s })\n", i, spec.Cases[i].Call)
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, `func studyCase(i int, f func() interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			studyfmt.Printf("\n%s\t%%d\tpanic\t%%q\n", i, studyfmt.Sprint(r))
+		}
+	}()
+	v := f()
+	studyfmt.Printf("\n%s\t%%d\tok\t%%q\n", i, studyfmt.Sprint(v))
+}
+`, caseMarker, caseMarker)
+	return []byte(b.String())
+}
+
+// outcome 单个用例的运行结果
+type outcome struct {
+	panicked bool
+	value    string
+}
+
+// parseOutcomes 从测试程序的输出中解析用例结果
+func parseOutcomes(stdout string) map[int]outcome {
+	outcomes := make(map[int]outcome)
+	sc := bufio.NewScanner(strings.NewReader(stdout))
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		i := strings.Index(line, caseMarker+"\t")
+		if i < 0 {
+			continue
+		}
+		fields := strings.SplitN(line[i+len(caseMarker)+1:], "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		idx, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		value, err := strconv.Unquote(fields[2])
+		if err != nil {
+			value = fields[2]
+		}
+		outcomes[idx] = outcome{panicked: fields[1] == "panic", value: value}
+	}
+	return outcomes
+}
+
+// signature 把函数签名格式化为不含参数名的形式，如 func[T any]([]T, int) []T
+func signature(sig *types.Signature, q types.Qualifier) string {
+	var b strings.Builder
+	b.WriteString("func")
+	if tps := sig.TypeParams(); tps.Len() > 0 {
+		b.WriteString("[")
+		for i := 0; i < tps.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			tp := tps.At(i)
+			b.WriteString(tp.Obj().Name() + " " + types.TypeString(tp.Constraint(), q))
+		}
+		b.WriteString("]")
+	}
+	b.WriteString("(" + tupleTypes(sig.Params(), sig.Variadic(), q) + ")")
+	switch res := sig.Results(); res.Len() {
+	case 0:
+	case 1:
+		b.WriteString(" " + types.TypeString(res.At(0).Type(), q))
+	default:
+		b.WriteString(" (" + tupleTypes(res, false, q) + ")")
+	}
+	return b.String()
+}
+
+// tupleTypes 列出参数或返回值的类型
+func tupleTypes(t *types.Tuple, variadic bool, q types.Qualifier) string {
+	parts := make([]string, t.Len())
+	for i := range parts {
+		typ := t.At(i).Type()
+		if variadic && i == t.Len()-1 {
+			parts[i] = "..." + types.TypeString(typ.(*types.Slice).Elem(), q)
+			continue
+		}
+		parts[i] = types.TypeString(typ, q)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compact 去掉空白，比较签名时忽略空格差异
+func compact(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// declares 判断文件是否声明了名为 name 的顶层函数
+func declares(file *ast.File, name string) bool {
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allChecks 列出规格中的全部检查项，用于代码无法解析时整体判为失败
+func allChecks(spec *Spec) []Check {
+	var checks []Check
+	for _, f := range spec.Functions {
+		checks = append(checks, Check{Kind: KindFunction, Name: f.Name, Expected: f.Signature})
+	}
+	for _, t := range spec.Types {
+		checks = append(checks, Check{Kind: KindType, Name: t.Name, Expected: t.Underlying})
+	}
+	for _, c := range spec.Cases {
+		checks = append(checks, Check{Kind: KindCase, Name: c.Name, Expected: c.Want})
+	}
+	for _, f := range spec.Stdout {
+		checks = append(checks, Check{Kind: KindStdout, Name: f.Name, Expected: f.Contains})
+	}
+	return checks
+}
+
+// add 记录一个检查项
+func (r *Report) add(c Check) {
+	r.Checks = append(r.Checks, c)
+	r.Total++
+	if c.Passed {
+		r.Passed++
+	}
+}
+
+// finish 计算得分（保留一位小数）
+func (r *Report) finish() *Report {
+	if r.Total > 0 {
+		r.Score = float64(r.Passed*1000/r.Total) / 10
+	}
+	return r
+}
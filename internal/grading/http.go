@@ -0,0 +1,84 @@
+package grading
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// kindIcons 各类检查项的图标
+var kindIcons = map[CheckKind]string{
+	KindFunction: "🔧",
+	KindType:     "🧱",
+	KindCase:     "🧪",
+	KindStdout:   "🖨️",
+}
+
+// APIHandler /api/v1/grade：GET ?day=3 或 POST {"day": 3} 为练习评分
+func (g *Grader) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var day int
+		switch r.Method {
+		case http.MethodGet:
+			n, err := strconv.Atoi(strings.TrimPrefix(r.URL.Query().Get("day"), "day"))
+			if err != nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.invalid_day")
+				return
+			}
+			day = n
+		case http.MethodPost:
+			var req struct {
+				Day int `json:"day"`
+			}
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			day = req.Day
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+			return
+		}
+
+		report, err := g.Grade(r.Context(), day)
+		if err != nil {
+			// 没有评分规格或练习为 404，运行队列已满为 429，其他为 500
+			handler.WriteError(w, r, err)
+			return
+		}
+		handler.SuccessResponse(w, report)
+	}
+}
+
+// PageHandler /grade/dayNN 评分结果页面
+func (g *Grader) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		day, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/grade/"), "day"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		report, err := g.Grade(r.Context(), day)
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+
+		icon := "❌"
+		switch {
+		case report.Passed == report.Total:
+			icon = "✅"
+		case report.Passed > 0:
+			icon = "🟡"
+		}
+		pages.Render(w, r, "grade", struct {
+			*Report
+			Icon  string
+			Icons map[CheckKind]string
+		}{report, icon, kindIcons})
+	}
+}
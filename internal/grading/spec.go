@@ -0,0 +1,94 @@
+package grading
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-web-api-study/internal/apperr"
+)
+
+// SpecDir 评分规格所在目录，相对仓库根目录，文件名为 dayNN.json
+const SpecDir = "exercises/specs"
+
+// 评分错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrNoSpec 这一天没有评分规格
+	ErrNoSpec = apperr.New(apperr.NotFound, "spec_not_found", "grading.no_spec")
+	// ErrNoExercise 这一天的练习还不存在
+	ErrNoExercise = apperr.New(apperr.NotFound, "exercise_not_found", "grading.no_exercise")
+	// ErrNoFile 评分规格指定的文件不在练习中
+	ErrNoFile = apperr.New(apperr.NotFound, "exercise_file_not_found", "grading.no_file")
+	// ErrInvalidSpec 评分规格无法解析或内容不合法
+	ErrInvalidSpec = apperr.New(apperr.Internal, "invalid_spec", "grading.invalid_spec")
+	errSpecDay     = apperr.New(apperr.Internal, ErrInvalidSpec.Code, "grading.spec_day")
+	errSpecCase    = apperr.New(apperr.Internal, ErrInvalidSpec.Code, "grading.spec_case")
+)
+
+// Spec 某一天练习的评分规格
+type Spec struct {
+	Day       int        `json:"day"`
+	File      string     `json:"file,omitempty"`    // 要评分的文件名，为空时选择定义了第一个期望函数的文件
+	Imports   []string   `json:"imports,omitempty"` // 测试用例表达式额外需要的标准库包
+	Functions []FuncSpec `json:"functions,omitempty"`
+	Types     []TypeSpec `json:"types,omitempty"`
+	Cases     []Case     `json:"cases,omitempty"`
+	Stdout    []Fragment `json:"stdout,omitempty"`
+}
+
+// FuncSpec 期望的顶层函数及其签名
+type FuncSpec struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"` // 不含参数名，如 func[T any]([]T, int) []T
+}
+
+// TypeSpec 期望的类型及其底层类型
+type TypeSpec struct {
+	Name       string `json:"name"`
+	Underlying string `json:"underlying"` // 如 struct{Name string; Age int}
+}
+
+// Case 表驱动的测试用例：对 Call 表达式求值，用 fmt.Sprint 的结果与 Want 比较
+type Case struct {
+	Name   string   `json:"name"`
+	Needs  []string `json:"needs,omitempty"` // 用例依赖的函数或类型，缺失或签名不符时直接判为失败
+	Call   string   `json:"call"`            // Go 表达式，如 removeAt([]int{1, 2, 3}, 1)
+	Want   string   `json:"want"`
+	Panics bool     `json:"panics,omitempty"` // 期望表达式 panic，Want 为空时不比较 panic 的值
+}
+
+// Fragment 期望出现在程序标准输出中的片段
+type Fragment struct {
+	Name     string `json:"name"`
+	Contains string `json:"contains"`
+}
+
+// LoadSpec 读取第 day 天的评分规格
+func LoadSpec(root string, day int) (*Spec, error) {
+	filename := filepath.Join(root, filepath.FromSlash(SpecDir), fmt.Sprintf("day%02d.json", day))
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, ErrNoSpec.With(day)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, ErrInvalidSpec.With(filepath.Base(filename)).Wrap(err)
+	}
+	if spec.Day == 0 {
+		spec.Day = day
+	}
+	if spec.Day != day {
+		return nil, errSpecDay.With(filepath.Base(filename), spec.Day)
+	}
+	for i, c := range spec.Cases {
+		if c.Name == "" || c.Call == "" {
+			return nil, errSpecCase.With(filepath.Base(filename), i+1)
+		}
+	}
+	return &spec, nil
+}
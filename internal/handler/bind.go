@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/router"
+)
+
+// ErrInvalidQuery 查询参数格式错误
+var ErrInvalidQuery = apperr.New(apperr.Validation, "invalid_query", "request.invalid_query")
+
+// errUnsupportedType 字段类型不支持绑定，属于代码错误，按内部错误返回
+var errUnsupportedType = errors.New("不支持绑定的字段类型")
+
+// Bind 把路径参数和查询参数写入结构体中带 path:"name" 或 query:"name" 标签的字段，
+// 支持字符串、整数、无符号整数、浮点数和布尔值；缺失的参数保持原值，格式错误时返回
+// router.ErrInvalidParam 或 ErrInvalidQuery，字段类型不支持时返回内部错误。
+// v 必须是结构体指针，嵌入的结构体会展开
+func Bind(r *http.Request, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return bindStruct(r, rv.Elem())
+}
+
+func bindStruct(r *http.Request, rv reflect.Value) error {
+	rt := rv.Type()
+	query := r.URL.Query()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if err := bindStruct(r, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name := sf.Tag.Get("path"); name != "" {
+			if s := r.PathValue(name); s != "" {
+				err := setField(fv, s)
+				if errors.Is(err, errUnsupportedType) {
+					return fmt.Errorf("字段 %s.%s: %w", rt.Name(), sf.Name, err)
+				}
+				if err != nil {
+					return router.ErrInvalidParam.With(name, s)
+				}
+			}
+		}
+		if name := sf.Tag.Get("query"); name != "" && query.Has(name) {
+			s := query.Get(name)
+			err := setField(fv, s)
+			if errors.Is(err, errUnsupportedType) {
+				return fmt.Errorf("字段 %s.%s: %w", rt.Name(), sf.Name, err)
+			}
+			if err != nil {
+				return ErrInvalidQuery.With(name, s)
+			}
+		}
+	}
+	return nil
+}
+
+// setField 把字符串解析为字段的类型并赋值
+func setField(fv reflect.Value, s string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedType, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/router"
+)
+
+type bindPage struct {
+	Limit  int  `query:"limit"`
+	Offset uint `query:"offset"`
+}
+
+type bindRequest struct {
+	bindPage
+	ID      int     `path:"id"`
+	Name    string  `query:"name"`
+	Score   float64 `query:"score"`
+	Active  bool    `query:"active"`
+	Small   int8    `query:"small"`
+	NoTag   string
+	private string `query:"private"` // 未导出的字段不绑定
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		path    map[string]string
+		initial bindRequest
+		want    bindRequest
+		wantErr error
+	}{
+		{
+			name:   "all kinds",
+			target: "/?name=go&score=1.5&active=true&small=-8&limit=10&offset=20",
+			path:   map[string]string{"id": "42"},
+			want:   bindRequest{bindPage: bindPage{Limit: 10, Offset: 20}, ID: 42, Name: "go", Score: 1.5, Active: true, Small: -8},
+		},
+		{
+			name:    "missing parameters keep initial values",
+			target:  "/",
+			initial: bindRequest{bindPage: bindPage{Limit: 20}, Name: "default"},
+			want:    bindRequest{bindPage: bindPage{Limit: 20}, Name: "default"},
+		},
+		{
+			name:    "empty query value overrides",
+			target:  "/?name=",
+			initial: bindRequest{Name: "default"},
+			want:    bindRequest{},
+		},
+		{
+			name:   "untagged and unexported fields ignored",
+			target: "/?NoTag=x&private=y",
+			want:   bindRequest{},
+		},
+		{name: "invalid path parameter", target: "/", path: map[string]string{"id": "abc"}, wantErr: router.ErrInvalidParam},
+		{name: "invalid integer", target: "/?limit=ten", wantErr: ErrInvalidQuery},
+		{name: "negative unsigned", target: "/?offset=-1", wantErr: ErrInvalidQuery},
+		{name: "integer overflow", target: "/?small=200", wantErr: ErrInvalidQuery},
+		{name: "invalid float", target: "/?score=high", wantErr: ErrInvalidQuery},
+		{name: "invalid bool", target: "/?active=maybe", wantErr: ErrInvalidQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			for k, v := range tt.path {
+				r.SetPathValue(k, v)
+			}
+			got := tt.initial
+			err := Bind(r, &got)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Bind() = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bind() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Bind() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindIgnoresNonStructPointer(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?limit=1", nil)
+	var page bindPage
+	for _, v := range []interface{}{nil, page, new(int)} {
+		if err := Bind(r, v); err != nil {
+			t.Errorf("Bind(%#v) = %v, want nil", v, err)
+		}
+	}
+}
+
+func TestBindUnsupportedType(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		path   map[string]string
+		v      interface{}
+	}{
+		{"slice query", "/?tags=a", nil, &struct {
+			Tags []string `query:"tags"`
+		}{}},
+		{"map path", "/", map[string]string{"id": "1"}, &struct {
+			ID map[string]int `path:"id"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			for k, v := range tt.path {
+				r.SetPathValue(k, v)
+			}
+			err := Bind(r, tt.v)
+			if err == nil {
+				t.Fatal("Bind() = nil, want error")
+			}
+			if kind := apperr.KindOf(err); kind != apperr.Internal {
+				t.Errorf("KindOf(%v) = %v, want internal", err, kind)
+			}
+			w := httptest.NewRecorder()
+			WriteError(w, r, err)
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("WriteError() status = %d, want %d", w.Code, http.StatusInternalServerError)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/i18n"
+)
+
+// kindStatus 错误类别对应的状态码
+var kindStatus = map[apperr.Kind]int{
+	apperr.Internal:     http.StatusInternalServerError,
+	apperr.NotFound:     http.StatusNotFound,
+	apperr.Conflict:     http.StatusConflict,
+	apperr.Validation:   http.StatusBadRequest,
+	apperr.Unauthorized: http.StatusUnauthorized,
+	apperr.Forbidden:    http.StatusForbidden,
+	apperr.RateLimited:  http.StatusTooManyRequests,
+}
+
+// StatusOf 返回错误对应的状态码，不是 apperr.Error 的错误为 500
+func StatusOf(err error) int {
+	return kindStatus[apperr.KindOf(err)]
+}
+
+// errorMessage 错误的状态码和按请求语言翻译的消息；内部错误写入日志，只返回"服务器内部错误"
+func errorMessage(r *http.Request, e *apperr.Error, err error) (int, string) {
+	locale := i18n.Of(r)
+	if e.Kind == apperr.Internal {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		return kindStatus[e.Kind], i18n.T(locale, "request.internal")
+	}
+	return kindStatus[e.Kind], e.Message(locale)
+}
+
+// WriteError 按 apperr 类别输出错误响应：code 为状态码，message 按请求语言翻译，
+// error 为稳定的错误码，有字段错误时 data.errors 为各字段的详情（problem+json 中为 errors 扩展成员）。
+// 不是 apperr.Error 的错误和 Internal 错误只返回"服务器内部错误"，原始错误写入日志
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	e := apperr.From(err)
+	status, message := errorMessage(r, e, err)
+	resp := Response{Code: status, Message: message, Error: e.Code}
+	if len(e.Fields) > 0 {
+		resp.Data = map[string]interface{}{"errors": e.LocalizedFields(i18n.Of(r))}
+	}
+	writeErrorResponse(w, r, resp)
+}
+
+// WritePageError 页面处理器的错误响应：状态码和消息与 WriteError 相同，正文为纯文本
+func WritePageError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := errorMessage(r, apperr.From(err), err)
+	http.Error(w, message, status)
+}
@@ -3,12 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+
+	"go-web-api-study/internal/i18n"
 )
 
 // Response 统一响应结构
 type Response struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
+	Error   string      `json:"error,omitempty"` // 错误响应中稳定的错误码，见 WriteError
 	Data    interface{} `json:"data,omitempty"`
 }
 
@@ -24,17 +27,44 @@ func SuccessResponse(w http.ResponseWriter, data interface{}) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// ErrorResponse 错误响应
-func ErrorResponse(w http.ResponseWriter, code int, message string) {
+// CreatedResponse 创建成功响应（201）
+func CreatedResponse(w http.ResponseWriter, data interface{}) {
 	response := Response{
-		Code:    code,
-		Message: message,
+		Code:    201,
+		Message: "created",
+		Data:    data,
 	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
 
+// ErrorResponse 错误响应，格式为 DefaultErrorFormat；需要按 Accept 头协商格式时用带请求的
+// LocalizedErrorResponse 或 ErrorResponseFrom（见 problem.go）
+func ErrorResponse(w http.ResponseWriter, code int, message string) {
+	writeErrorResponse(w, nil, Response{Code: code, Message: message})
+}
+
+// LocalizedErrorResponse 错误响应，消息按请求语言翻译消息目录中的 key
+func LocalizedErrorResponse(w http.ResponseWriter, r *http.Request, code int, key string, args ...interface{}) {
+	writeErrorResponse(w, r, Response{Code: code, Message: i18n.T(i18n.Of(r), key, args...)})
+}
+
+// ErrorResponseFrom 错误响应，err 是可翻译的错误时按请求语言翻译，否则使用 err.Error()
+func ErrorResponseFrom(w http.ResponseWriter, r *http.Request, code int, err error) {
+	writeErrorResponse(w, r, Response{Code: code, Message: i18n.Message(i18n.Of(r), err)})
+}
+
+// MethodNotAllowed 405 响应，用作 router.Router.MethodNotAllowed（Allow 头由路由器设置）
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+}
+
+// NotFound 404 响应，用作 router.Router.NotFound
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	LocalizedErrorResponse(w, r, http.StatusNotFound, "request.not_found", r.URL.Path)
+}
+
 // HelloHandler 示例处理器
 func HelloHandler(w http.ResponseWriter, r *http.Request) {
 	data := map[string]interface{}{
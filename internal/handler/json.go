@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"go-web-api-study/internal/apperr"
+)
+
+// DefaultMaxBodyBytes JSON 请求体的默认大小上限
+const DefaultMaxBodyBytes = 1 << 20
+
+// options JSON 处理器的选项
+type options struct {
+	maxBytes    int64
+	status      int
+	location    func(interface{}) string
+	errorStatus func(error) int
+}
+
+// Option JSON 处理器的选项
+type Option func(*options)
+
+// MaxBytes 设置请求体的大小上限，超过时返回 413
+func MaxBytes(n int64) Option {
+	return func(o *options) { o.maxBytes = n }
+}
+
+// Created 成功时返回 201，location 不为空时用它生成 Location 头
+func Created[Resp any](location func(Resp) string) Option {
+	return func(o *options) {
+		o.status = http.StatusCreated
+		if location != nil {
+			o.location = func(v interface{}) string { return location(v.(Resp)) }
+		}
+	}
+}
+
+// NoContent 成功时返回 204，不输出响应体
+func NoContent() Option {
+	return func(o *options) { o.status = http.StatusNoContent }
+}
+
+// ErrorStatus 为还没有使用 apperr 的业务错误设置状态码映射，错误消息原样返回给客户端；
+// 返回 0 时交给 WriteError 作为内部错误处理
+func ErrorStatus(fn func(error) int) Option {
+	return func(o *options) { o.errorStatus = fn }
+}
+
+// JSON 把业务函数包装为 HTTP 处理器：
+//  1. 有请求体时按 JSON 解析到 Req，不允许未知字段，超过大小上限返回 413
+//  2. 按 path、query 标签绑定路径参数和查询参数（见 Bind）
+//  3. 按 validate 标签和 Validator 校验（见 Validate），失败时返回 400 和各字段的错误
+//  4. 调用 fn，错误用 WriteError 按 apperr 类别映射为状态码，消息按请求语言翻译
+//  5. 结果用统一响应结构 Response 输出
+func JSON[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error), opts ...Option) http.HandlerFunc {
+	o := options{maxBytes: DefaultMaxBodyBytes, status: http.StatusOK}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req Req
+		if err := decodeJSON(w, r, &req, o.maxBytes); err != nil {
+			writeDecodeError(w, r, err)
+			return
+		}
+		if err := Bind(r, &req); err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		if err := Validate(&req); err != nil {
+			writeAdapterError(w, r, err, o.errorStatus)
+			return
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			writeAdapterError(w, r, err, o.errorStatus)
+			return
+		}
+
+		switch o.status {
+		case http.StatusNoContent:
+			w.WriteHeader(http.StatusNoContent)
+		case http.StatusCreated:
+			if o.location != nil {
+				w.Header().Set("Location", o.location(resp))
+			}
+			CreatedResponse(w, resp)
+		default:
+			SuccessResponse(w, resp)
+		}
+	}
+}
+
+// decodeJSON 解析请求体；没有请求体时 v 保持零值，由校验决定是否缺少字段
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}, maxBytes int64) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+	// 请求体中只能有一个 JSON 值
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+	return nil
+}
+
+var errTrailingData = errors.New("请求体中有多余的数据")
+
+// writeDecodeError 请求体解析错误：超过大小上限为 413，未知字段和格式错误为 400
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		LocalizedErrorResponse(w, r, http.StatusRequestEntityTooLarge, "request.too_large", tooLarge.Limit)
+		return
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.unknown_field", strings.Trim(field, `"`))
+		return
+	}
+	LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+}
+
+// writeAdapterError 有 ErrorStatus 映射时按它的状态码返回错误消息，否则交给 WriteError
+func writeAdapterError(w http.ResponseWriter, r *http.Request, err error, errorStatus func(error) int) {
+	if errorStatus != nil && apperr.KindOf(err) == apperr.Internal {
+		if code := errorStatus(err); code != 0 {
+			ErrorResponseFrom(w, r, code, err)
+			return
+		}
+	}
+	WriteError(w, r, err)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type echoRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestJSONDecodeErrors(t *testing.T) {
+	h := JSON(func(_ context.Context, req echoRequest) (echoRequest, error) {
+		return req, nil
+	}, MaxBytes(32))
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{"valid", `{"name":"go"}`, http.StatusOK, "success"},
+		{"unknown field", `{"name":"go","age":3}`, http.StatusBadRequest, "Unknown field age in request"},
+		{"trailing data", `{"name":"go"} {}`, http.StatusBadRequest, "Malformed request"},
+		{"syntax error", `{"name":`, http.StatusBadRequest, "Malformed request"},
+		{"wrong type", `{"name":1}`, http.StatusBadRequest, "Malformed request"},
+		{"too large", `{"name":"` + strings.Repeat("x", 64) + `"}`, http.StatusRequestEntityTooLarge, "Request body exceeds 32 bytes"},
+		{"empty body is validated", ``, http.StatusBadRequest, "name is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/?lang=en", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h(w, r)
+
+			var resp Response
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if w.Code != tt.wantStatus || resp.Message != tt.wantMessage {
+				t.Errorf("got %d %q, want %d %q", w.Code, resp.Message, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestWriteDecodeErrorUnknownField(t *testing.T) {
+	// writeDecodeError 依赖 encoding/json 的错误文本取出字段名，这里确认格式没有变化
+	var v echoRequest
+	dec := json.NewDecoder(strings.NewReader(`{"nickname":"go"}`))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&v)
+	if err == nil {
+		t.Fatal("Decode() = nil, want unknown field error")
+	}
+
+	r := httptest.NewRequest("POST", "/?lang=en", nil)
+	w := httptest.NewRecorder()
+	writeDecodeError(w, r, err)
+	if want := `Unknown field nickname in request`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("body = %s, want message %q (decode error %q)", w.Body.String(), want, err)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType RFC 9457 错误响应的媒体类型
+const ProblemContentType = "application/problem+json"
+
+// ErrorFormat 错误响应的格式
+type ErrorFormat int
+
+// 错误响应格式
+const (
+	FormatEnvelope ErrorFormat = iota // 统一响应结构 {code, message, error, data}
+	FormatProblem                     // RFC 9457 application/problem+json
+)
+
+// DefaultErrorFormat 服务器默认的错误响应格式；请求的 Accept 头包含
+// application/problem+json 时总是使用 FormatProblem
+var DefaultErrorFormat = FormatEnvelope
+
+// ParseErrorFormat 解析配置中的格式名：envelope 或 problem
+func ParseErrorFormat(s string) (ErrorFormat, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "envelope":
+		return FormatEnvelope, true
+	case "problem":
+		return FormatProblem, true
+	}
+	return FormatEnvelope, false
+}
+
+// Problem RFC 9457 问题详情。Extensions 中的成员与标准成员并列输出，
+// 如 error（稳定的错误码）、errors（字段错误详情）和 request_id
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON 把扩展成员展开到顶层，标准成员不会被同名扩展覆盖
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// ProblemFrom 把统一响应结构转换为问题详情：type 为 about:blank，title 为状态码的标准短语，
+// detail 为消息，instance 为请求路径；错误码、data 中的成员和 X-Request-Id 作为扩展成员
+func ProblemFrom(r *http.Request, resp Response) Problem {
+	p := Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(resp.Code),
+		Status:     resp.Code,
+		Detail:     resp.Message,
+		Extensions: map[string]interface{}{},
+	}
+	if p.Title == "" {
+		p.Title = "HTTP " + strconv.Itoa(resp.Code)
+	}
+	if resp.Error != "" {
+		p.Extensions["error"] = resp.Error
+	}
+	switch data := resp.Data.(type) {
+	case nil:
+	case map[string]interface{}:
+		for k, v := range data {
+			p.Extensions[k] = v
+		}
+	default:
+		p.Extensions["data"] = data
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+		if id := r.Header.Get("X-Request-Id"); id != "" {
+			p.Extensions["request_id"] = id
+		}
+	}
+	return p
+}
+
+// errorFormat 请求使用的错误格式：Accept 头中明确接受 application/problem+json 时为 FormatProblem，
+// 否则为 DefaultErrorFormat。r 为 nil 时只看服务器配置
+func errorFormat(r *http.Request) ErrorFormat {
+	if r != nil && acceptsProblem(r.Header.Values("Accept")) {
+		return FormatProblem
+	}
+	return DefaultErrorFormat
+}
+
+// acceptsProblem Accept 头中是否有 q 不为 0 的 application/problem+json
+func acceptsProblem(accept []string) bool {
+	for _, header := range accept {
+		for _, part := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != ProblemContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// writeErrorResponse 按请求协商的格式输出错误响应，所有错误响应函数都经过这里
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, resp Response) {
+	if r != nil {
+		w.Header().Add("Vary", "Accept")
+	}
+	if errorFormat(r) == FormatProblem {
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(ProblemFrom(r, resp))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"go-web-api-study/internal/model"
+	"go-web-api-study/internal/router"
+	"go-web-api-study/internal/service"
+)
+
+// maxUserBody 用户接口请求体的大小上限
+const maxUserBody = 4096
+
+// UserHandler 用户接口：注册、查询、更新、删除和登录
+type UserHandler struct {
+	svc service.UserService
+}
+
+// NewUserHandler 创建用户接口处理器
+func NewUserHandler(svc service.UserService) *UserHandler {
+	return &UserHandler{svc: svc}
+}
+
+// userIDRequest 路径中的用户 ID
+type userIDRequest struct {
+	ID int `path:"id" json:"-" validate:"min=1"`
+}
+
+// updateUserRequest PATCH /users/{id} 的请求：路径中的 ID 和请求体中要修改的字段
+type updateUserRequest struct {
+	userIDRequest
+	model.UpdateUserRequest
+}
+
+// Routes 在路由组中注册用户接口，组前缀通常为 /api/v1：
+// POST /users 注册（201），GET、PATCH、DELETE /users/{id}（删除成功返回 204），POST /auth/login 登录
+func (h *UserHandler) Routes(g *router.Group) {
+	limit := MaxBytes(maxUserBody)
+	location := func(u *model.User) string {
+		return g.Path("/users/" + strconv.Itoa(u.ID))
+	}
+
+	g.HandleFunc("POST /users", JSON(h.create, Created(location), limit))
+	g.HandleFunc("GET /users/{id}", JSON(h.get))
+	g.HandleFunc("PATCH /users/{id}", JSON(h.update, limit))
+	g.HandleFunc("DELETE /users/{id}", JSON(h.delete, NoContent()))
+	g.HandleFunc("POST /auth/login", JSON(h.login, limit))
+}
+
+func (h *UserHandler) create(_ context.Context, req model.CreateUserRequest) (*model.User, error) {
+	return h.svc.CreateUser(req)
+}
+
+func (h *UserHandler) get(_ context.Context, req userIDRequest) (*model.User, error) {
+	return h.svc.GetUserByID(req.ID)
+}
+
+func (h *UserHandler) update(_ context.Context, req updateUserRequest) (*model.User, error) {
+	return h.svc.UpdateUser(req.ID, req.UpdateUserRequest)
+}
+
+func (h *UserHandler) delete(_ context.Context, req userIDRequest) (struct{}, error) {
+	return struct{}{}, h.svc.DeleteUser(req.ID)
+}
+
+func (h *UserHandler) login(_ context.Context, req model.LoginRequest) (*model.LoginResponse, error) {
+	return h.svc.Login(req)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go-web-api-study/internal/apperr"
+)
+
+// Validator 需要跨字段检查的请求类型实现它，在 validate 标签检查通过后调用
+type Validator interface {
+	Validate() error
+}
+
+// Validate 按 validate 标签检查结构体字段（与 model 中的写法相同），未通过时返回带字段详情的 apperr.Invalid。支持的规则：
+// required、omitempty、min、max（字符串为字符数，数字为数值，切片为长度）、email、oneof=a b c。
+// 嵌入的结构体会展开检查。标签写错（不认识的规则、min/max 的参数不是数字或字段类型不支持）
+// 时返回普通错误，按内部错误处理并写入日志，以便在开发时发现拼写错误
+func Validate(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []apperr.FieldError
+	if err := validateStruct(rv, &fields); err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return apperr.Invalid(fields...)
+	}
+	if val, ok := v.(Validator); ok {
+		return val.Validate()
+	}
+	return nil
+}
+
+func validateStruct(rv reflect.Value, errs *[]apperr.FieldError) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if err := validateStruct(fv, errs); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		if tag == "" || !sf.IsExported() {
+			continue
+		}
+		name := fieldName(sf)
+		rules := strings.Split(tag, ",")
+		if rules[0] == "omitempty" {
+			if fv.IsZero() {
+				continue
+			}
+			rules = rules[1:]
+		}
+		for _, rule := range rules {
+			rule, param, _ := strings.Cut(rule, "=")
+			ok, err := checkRule(fv, rule, param)
+			if err != nil {
+				return fmt.Errorf("字段 %s.%s: %w", rt.Name(), sf.Name, err)
+			}
+			if !ok {
+				*errs = append(*errs, apperr.FieldError{Field: name, Rule: ruleKey(fv, rule), Param: param})
+				break // 每个字段只报告第一条未通过的规则
+			}
+		}
+	}
+	return nil
+}
+
+// checkRule 检查字段值是否满足一条规则，规则本身写错时返回错误
+func checkRule(fv reflect.Value, rule, param string) (bool, error) {
+	switch rule {
+	case "required":
+		return !fv.IsZero(), nil
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return false, fmt.Errorf("validate 规则 %s 的参数不是数字: %q", rule, param)
+		}
+		n, ok := measure(fv)
+		if !ok {
+			return false, fmt.Errorf("validate 规则 %s 不支持 %s 类型", rule, fv.Type())
+		}
+		if rule == "min" {
+			return n >= limit, nil
+		}
+		return n <= limit, nil
+	case "email":
+		addr, err := mail.ParseAddress(fv.String())
+		return err == nil && addr.Address == fv.String(), nil
+	case "oneof":
+		s := fmt.Sprint(fv.Interface())
+		for _, option := range strings.Fields(param) {
+			if s == option {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("不支持的 validate 规则: %s", rule)
+}
+
+// measure 字符串的字符数、切片和 map 的长度或数字的值
+func measure(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(fv.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(fv.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	}
+	return 0, false
+}
+
+// ruleKey 消息目录中的规则名：min、max 对字符串和集合使用长度的说法
+func ruleKey(fv reflect.Value, rule string) string {
+	if rule != "min" && rule != "max" {
+		return rule
+	}
+	switch fv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rule + "_len"
+	}
+	return rule
+}
+
+// fieldName 错误中使用的字段名：path、query 或 json 标签中的名字，都没有时为 Go 字段名
+func fieldName(sf reflect.StructField) string {
+	for _, key := range []string{"path", "query", "json"} {
+		if name, _, _ := strings.Cut(sf.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
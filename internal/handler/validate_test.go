@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-web-api-study/internal/apperr"
+)
+
+type validateEmbedded struct {
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+type validateRequest struct {
+	validateEmbedded
+	Name  string   `json:"name" validate:"required,min=3,max=5"`
+	Age   int      `query:"age" validate:"omitempty,min=18,max=99"`
+	Role  string   `json:"role,omitempty" validate:"omitempty,oneof=admin user"`
+	Tags  []string `json:"tags" validate:"max=2"`
+	Note  string   `validate:"max=4"`
+	plain string   `validate:"required"` // 未导出的字段不检查
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateRequest{Name: "张三丰", Age: 30, Role: "admin", Tags: []string{"a"}}
+	tests := []struct {
+		name   string
+		modify func(*validateRequest)
+		want   []apperr.FieldError // 为空表示校验通过
+	}{
+		{"valid", func(*validateRequest) {}, nil},
+		{"required", func(r *validateRequest) { r.Name = "" }, []apperr.FieldError{{Field: "name", Rule: "required"}}},
+		// 字符串按字符数计算，"张三丰" 是 3 个字符
+		{"min_len counts runes", func(r *validateRequest) { r.Name = "张三" }, []apperr.FieldError{{Field: "name", Rule: "min_len", Param: "3"}}},
+		{"max_len", func(r *validateRequest) { r.Name = "abcdef" }, []apperr.FieldError{{Field: "name", Rule: "max_len", Param: "5"}}},
+		{"omitempty skips zero", func(r *validateRequest) { r.Age = 0; r.Role = "" }, nil},
+		{"min number", func(r *validateRequest) { r.Age = 17 }, []apperr.FieldError{{Field: "age", Rule: "min", Param: "18"}}},
+		{"max number", func(r *validateRequest) { r.Age = 100 }, []apperr.FieldError{{Field: "age", Rule: "max", Param: "99"}}},
+		{"oneof", func(r *validateRequest) { r.Role = "root" }, []apperr.FieldError{{Field: "role", Rule: "oneof", Param: "admin user"}}},
+		{"max slice length", func(r *validateRequest) { r.Tags = []string{"a", "b", "c"} }, []apperr.FieldError{{Field: "tags", Rule: "max_len", Param: "2"}}},
+		{"go field name without tags", func(r *validateRequest) { r.Note = "hello" }, []apperr.FieldError{{Field: "Note", Rule: "max_len", Param: "4"}}},
+		{"embedded struct", func(r *validateRequest) { r.Email = "not-an-email" }, []apperr.FieldError{{Field: "email", Rule: "email"}}},
+		{"email with display name", func(r *validateRequest) { r.Email = "Bob <bob@example.com>" }, []apperr.FieldError{{Field: "email", Rule: "email"}}},
+		{"valid email", func(r *validateRequest) { r.Email = "bob@example.com" }, nil},
+		{"all failing fields", func(r *validateRequest) { r.Name = ""; r.Age = 1 }, []apperr.FieldError{
+			{Field: "name", Rule: "required"},
+			{Field: "age", Rule: "min", Param: "18"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			err := Validate(&req)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var e *apperr.Error
+			if !errors.As(err, &e) || e.Kind != apperr.Validation {
+				t.Fatalf("Validate() = %v, want validation error", err)
+			}
+			if !reflect.DeepEqual(e.Fields, tt.want) {
+				t.Errorf("Fields = %+v, want %+v", e.Fields, tt.want)
+			}
+		})
+	}
+}
+
+type crossFieldRequest struct {
+	Password string `validate:"required"`
+	Confirm  string
+}
+
+var errMismatch = errors.New("mismatch")
+
+func (r crossFieldRequest) Validate() error {
+	if r.Password != r.Confirm {
+		return errMismatch
+	}
+	return nil
+}
+
+func TestValidateCallsValidatorAfterTags(t *testing.T) {
+	tests := []struct {
+		name string
+		req  crossFieldRequest
+		want error
+	}{
+		{"tags fail first", crossFieldRequest{}, apperr.Invalid()},
+		{"validator fails", crossFieldRequest{Password: "a", Confirm: "b"}, errMismatch},
+		{"both pass", crossFieldRequest{Password: "a", Confirm: "a"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.req)
+			if (tt.want == nil && err != nil) || !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBadTags(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"unknown rule", &struct {
+			Name string `validate:"requird"`
+		}{Name: "x"}},
+		{"non-numeric min", &struct {
+			Name string `validate:"min=abc"`
+		}{}},
+		{"max on unsupported type", &struct {
+			Flag bool `validate:"max=1"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if err == nil {
+				t.Fatal("Validate() = nil, want error")
+			}
+			if kind := apperr.KindOf(err); kind != apperr.Internal {
+				t.Errorf("KindOf(%v) = %v, want internal", err, kind)
+			}
+		})
+	}
+}
+
+func TestValidateIgnoresNonStruct(t *testing.T) {
+	for _, v := range []interface{}{nil, 42, "text", []int{1}} {
+		if err := Validate(v); err != nil {
+			t.Errorf("Validate(%#v) = %v, want nil", v, err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package i18n 界面和接口消息的中英文翻译：消息按键查找，语言依次取自 ?lang= 参数、
+// lang Cookie 和 Accept-Language 请求头，默认中文
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale 界面语言
+type Locale string
+
+// 支持的语言
+const (
+	ZH Locale = "zh"
+	EN Locale = "en"
+
+	Default = ZH
+)
+
+// Supported 支持的全部语言，按语言切换链接的显示顺序
+var Supported = []Locale{ZH, EN}
+
+// 选择语言的查询参数和 Cookie
+const (
+	QueryParam = "lang"
+	CookieName = "lang"
+)
+
+// Name 语言的显示名称
+func (l Locale) Name() string {
+	if l == EN {
+		return "English"
+	}
+	return "中文"
+}
+
+// Parse 解析语言标签，如 zh、zh-CN、zh-Hans-CN、en-US，不支持的语言返回 false
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, l := range Supported {
+		if tag == string(l) {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// FromAcceptLanguage 按 Accept-Language 中的权重选出第一个支持的语言，没有时为默认语言
+func FromAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		l, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{l, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+	// 权重相同时保持请求头中的顺序
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+// FromRequest 按 ?lang=、lang Cookie、Accept-Language 的顺序选择语言
+func FromRequest(r *http.Request) Locale {
+	if l, ok := Parse(r.URL.Query().Get(QueryParam)); ok {
+		return l
+	}
+	if c, err := r.Cookie(CookieName); err == nil {
+		if l, ok := Parse(c.Value); ok {
+			return l
+		}
+	}
+	return FromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+type contextKey struct{}
+
+// WithLocale 把语言放入 context
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext 返回 context 中的语言，没有时为默认语言；用于拿不到请求的地方，如运行器的状态提示
+func FromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return l
+	}
+	return Default
+}
+
+// Of 返回请求的语言：经过语言中间件时取 context 中的值，否则直接从请求中解析
+func Of(r *http.Request) Locale {
+	if l, ok := r.Context().Value(contextKey{}).(Locale); ok {
+		return l
+	}
+	return FromRequest(r)
+}
+
+// T 返回 key 在语言 l 下的消息，带参数时按 fmt 格式化；缺少翻译时回退到中文，键不存在时返回键本身
+func T(l Locale, key string, args ...interface{}) string {
+	texts, ok := messages[key]
+	if !ok {
+		return key
+	}
+	text, ok := texts[l]
+	if !ok {
+		text = texts[Default]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Error 可翻译的错误：Error() 返回中文消息，接口按请求语言用 Message 翻译
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+// NewError 创建可翻译的错误，通常作为包级哨兵错误
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return T(Default, e.Key, e.Args...)
+}
+
+// Is 键相同即视为同一错误，带参数的错误也能与哨兵错误比较
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Key == e.Key
+}
+
+// With 返回带格式化参数的同类错误
+func (e *Error) With(args ...interface{}) *Error {
+	return &Error{Key: e.Key, Args: args}
+}
+
+// Message 返回错误在语言 l 下的消息：错误链中有 *Error 时翻译它，否则为 err.Error()
+func Message(l Locale, err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return T(l, e.Key, e.Args...)
+	}
+	return err.Error()
+}
+
+// Messages 返回键以 prefix 开头的全部消息在语言 l 下的文本，键去掉 prefix；用于把页面脚本中的文字交给前端
+func Messages(l Locale, prefix string) map[string]string {
+	out := make(map[string]string)
+	for key := range messages {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			out[name] = T(l, key)
+		}
+	}
+	return out
+}
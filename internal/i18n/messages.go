@@ -0,0 +1,318 @@
+package i18n
+
+// messages 消息目录：键 -> 语言 -> 文本，新增键时两种语言都要填写
+var messages = map[string]map[Locale]string{
+	// 通用
+	"common.none":      {ZH: "无", EN: "none"},
+	"common.separator": {ZH: "、", EN: ", "},
+	"common.language":  {ZH: "语言", EN: "Language"},
+
+	// 导航
+	"nav.home":      {ZH: "🏠 首页", EN: "🏠 Home"},
+	"nav.exercises": {ZH: "📝 练习", EN: "📝 Exercises"},
+	"nav.gobase":    {ZH: "🔧 基础模块", EN: "🔧 Modules"},
+	"nav.progress":  {ZH: "📈 学习进度", EN: "📈 Progress"},
+	"nav.quiz":      {ZH: "📝 测验列表", EN: "📝 Quizzes"},
+	"nav.source":    {ZH: "👀 查看源码", EN: "👀 View source"},
+	"nav.back":      {ZH: "👀 返回查看", EN: "👀 Back to source"},
+
+	// 首页
+	"home.title":          {ZH: "Go Web API 学习项目", EN: "Go Web API Study Project"},
+	"home.resources":      {ZH: "📚 学习资源", EN: "📚 Resources"},
+	"home.goals":          {ZH: "🎯 学习目标", EN: "🎯 Goals"},
+	"home.roadmap":        {ZH: "🗺️ 学习路线", EN: "🗺️ Roadmap"},
+	"home.roadmap_alt":    {ZH: "学习路线图", EN: "Learning roadmap"},
+	"home.structure_alt":  {ZH: "项目结构图", EN: "Project structure"},
+	"home.link.exercises": {ZH: "📝 每日练习", EN: "📝 Daily exercises"},
+	"home.link.gobase":    {ZH: "🔧 Go基础模块", EN: "🔧 Go basics"},
+	"home.link.progress":  {ZH: "📈 学习进度", EN: "📈 Progress"},
+	"home.link.search":    {ZH: "🔎 搜索", EN: "🔎 Search"},
+	"home.link.quiz":      {ZH: "📝 要点测验", EN: "📝 Quizzes"},
+	"home.link.review":    {ZH: "🔁 今日复习", EN: "🔁 Today's review"},
+	"home.link.bench":     {ZH: "⏱️ 基准测试", EN: "⏱️ Benchmarks"},
+	"home.link.api":       {ZH: "🌐 API示例", EN: "🌐 API example"},
+	"home.link.health":    {ZH: "💚 健康检查", EN: "💚 Health check"},
+	"home.goal.basics":    {ZH: "掌握Go语言基础语法", EN: "Learn the Go language basics"},
+	"home.goal.http":      {ZH: "学习HTTP服务开发", EN: "Build HTTP services"},
+	"home.goal.rest":      {ZH: "构建RESTful API", EN: "Design RESTful APIs"},
+	"home.goal.database":  {ZH: "数据库操作与ORM", EN: "Work with databases and ORMs"},
+	"home.goal.routing":   {ZH: "中间件和路由", EN: "Middleware and routing"},
+
+	// 练习和模块列表
+	"exercises.title":   {ZH: "每日练习 - Go学习", EN: "Daily Exercises - Go Study"},
+	"exercises.heading": {ZH: "📝 每日练习", EN: "📝 Daily Exercises"},
+	"card.view_source":  {ZH: "查看源代码", EN: "View source"},
+	"gobase.title":      {ZH: "Go基础学习模块", EN: "Go Basics Modules"},
+	"gobase.heading":    {ZH: "🔧 Go基础学习模块", EN: "🔧 Go Basics Modules"},
+	"gobase.minutes":    {ZH: "约 %d 分钟", EN: "about %d min"},
+	"gobase.prereqs":    {ZH: "前置模块", EN: "Prerequisites"},
+	"gobase.days":       {ZH: "对应练习", EN: "Exercises"},
+	"gobase.graph":      {ZH: "🧭 前置关系图", EN: "🧭 Prerequisite Graph"},
+	"gobase.graph_hint": {ZH: "从左到右学习，每一列只依赖左侧各列中的模块。", EN: "Study from left to right; each column only depends on the columns to its left."},
+	"gobase.level":      {ZH: "第 %d 阶段", EN: "Stage %d"},
+	"gobase.requires":   {ZH: "依赖", EN: "Requires"},
+	"module.prev":       {ZH: "上一个", EN: "Previous"},
+	"module.next":       {ZH: "下一个", EN: "Next"},
+
+	// 源码页面上的练习导航
+	"exercise.compare": {ZH: "🔍 对比 gobase 输出", EN: "🔍 Compare with gobase"},
+	"exercise.grade":   {ZH: "🧪 评分", EN: "🧪 Grade"},
+	"exercise.edit":    {ZH: "✏️ 编辑", EN: "✏️ Edit"},
+
+	// 源码查看，source.js. 开头的消息由页面脚本使用
+	"source.title":        {ZH: "%s - 源代码查看", EN: "%s - Source"},
+	"source.command":      {ZH: "💡 命令行运行", EN: "💡 Run from the command line"},
+	"source.run":          {ZH: "▶️ 运行", EN: "▶️ Run"},
+	"source.outline":      {ZH: "🧩 大纲", EN: "🧩 Outline"},
+	"source.js.timeout":   {ZH: "⏰ 超时", EN: "⏰ Timed out"},
+	"source.js.done":      {ZH: "✅ 完成", EN: "✅ Done"},
+	"source.js.exit_code": {ZH: "❌ 退出码", EN: "❌ Exit code"},
+	"source.js.deleted":   {ZH: "（已删除）", EN: "(deleted) "},
+
+	// 符号定义
+	"symbols.title": {ZH: "%s - 符号定义", EN: "%s - Definitions"},
+	"symbols.found": {ZH: "找到 %d 个定义：", EN: "Found %d definitions:"},
+	"symbols.none":  {ZH: "gobase 和 exercises 中没有找到这个名称的定义", EN: "No definition with this name in gobase or exercises"},
+
+	// 搜索
+	"search.title":       {ZH: "搜索 - Go学习", EN: "Search - Go Study"},
+	"search.heading":     {ZH: "🔎 搜索学习资料", EN: "🔎 Search the study material"},
+	"search.placeholder": {ZH: "例如: select 超时、WaitGroup、学习要点", EN: "e.g. select timeout, WaitGroup, key points"},
+	"search.submit":      {ZH: "搜索", EN: "Search"},
+	"search.symbols":     {ZH: "🧩 符号", EN: "🧩 Symbols"},
+	"search.fulltext":    {ZH: "📄 全文（%d 个文件）", EN: "📄 Full text (%d files)"},
+	"search.none":        {ZH: "没有找到同时包含全部关键词的文件", EN: "No file contains all of the keywords"},
+	"search.score":       {ZH: "得分 %.2f", EN: "score %.2f"},
+	"kind.gobase":        {ZH: "🔧 基础模块", EN: "🔧 Module"},
+	"kind.exercises":     {ZH: "📝 练习", EN: "📝 Exercise"},
+	"kind.docs":          {ZH: "📄 文档", EN: "📄 Document"},
+
+	// 练习编辑器，editor.js. 开头的消息由页面脚本使用
+	"editor.title":            {ZH: "%s - 编辑", EN: "%s - Edit"},
+	"editor.save":             {ZH: "💾 保存 (Ctrl+S)", EN: "💾 Save (Ctrl+S)"},
+	"editor.format":           {ZH: "🧹 格式化", EN: "🧹 Format"},
+	"editor.check":            {ZH: "🔍 检查", EN: "🔍 Check"},
+	"editor.format_on_save":   {ZH: "保存时格式化", EN: "Format on save"},
+	"editor.share":            {ZH: "🔗 分享", EN: "🔗 Share"},
+	"editor.ttl_never":        {ZH: "永不过期", EN: "Never expires"},
+	"editor.ttl_day":          {ZH: "1 天后过期", EN: "Expires in 1 day"},
+	"editor.ttl_week":         {ZH: "7 天后过期", EN: "Expires in 7 days"},
+	"editor.js.no_problems":   {ZH: "✅ 没有发现问题", EN: "✅ No problems found"},
+	"editor.js.working":       {ZH: "⏳ 处理中...", EN: "⏳ Working..."},
+	"editor.js.checked":       {ZH: "已检查", EN: "Checked"},
+	"editor.js.formatted":     {ZH: "已格式化", EN: "Formatted"},
+	"editor.js.not_formatted": {ZH: "有语法错误，未格式化", EN: "Syntax errors, not formatted"},
+	"editor.js.saved":         {ZH: "已保存", EN: "Saved"},
+	"editor.js.backup":        {ZH: "，旧版本备份在 ", EN: ", previous version backed up to "},
+	"editor.js.sharing":       {ZH: "⏳ 分享中...", EN: "⏳ Sharing..."},
+	"editor.js.copied":        {ZH: "（已复制）", EN: " (copied)"},
+
+	// 学习进度，progress.js. 开头的消息由页面脚本使用
+	"progress.title":          {ZH: "学习进度 - Go学习", EN: "Progress - Go Study"},
+	"progress.heading":        {ZH: "📈 学习进度", EN: "📈 Progress"},
+	"progress.percent":        {ZH: "总完成率", EN: "Overall completion"},
+	"progress.current_streak": {ZH: "当前连续完成天数", EN: "Current streak (days)"},
+	"progress.longest_streak": {ZH: "最长连续完成天数", EN: "Longest streak (days)"},
+	"progress.next":           {ZH: "推荐下一天", EN: "Up next"},
+	"progress.all_done":       {ZH: "🎉 全部完成", EN: "🎉 All done"},
+	"progress.days":           {ZH: "🗓️ 42天练习（存在且能编译视为完成）", EN: "🗓️ 42-day exercises (done when the file exists and compiles)"},
+	"progress.day_todo":       {ZH: "未开始", EN: "Not started"},
+	"progress.day_done":       {ZH: "已完成", EN: "Done"},
+	"progress.day_pending":    {ZH: "运行队列繁忙，稍后刷新查看编译结果", EN: "The run queue is busy, refresh later for the build result"},
+	"progress.day_broken":     {ZH: "编译失败:", EN: "Build failed:"},
+	"progress.plan":           {ZH: "✅ 学习计划任务（docs/learning_plan.md）", EN: "✅ Study plan tasks (docs/learning_plan.md)"},
+	"progress.js.save_failed": {ZH: "保存失败", EN: "Failed to save"},
+
+	// 间隔重复复习，review.js. 开头的消息由页面脚本使用，{due} 等占位符由脚本替换
+	"review.title":         {ZH: "今日复习 - Go学习", EN: "Today's Review - Go Study"},
+	"review.heading":       {ZH: "🔁 今日复习 · %s", EN: "🔁 Today's review · %s"},
+	"review.intro":         {ZH: "已完成的练习和模块按 SM-2 间隔重复安排复习，共 %d 张卡片，今天到期 %d 张。复习后按回忆程度打分，决定下次复习的时间。", EN: "Finished exercises and modules are scheduled with SM-2 spaced repetition: %d cards in total, %d due today. Rate how well you remembered after each review to schedule the next one."},
+	"review.nothing_due":   {ZH: "🎉 今天没有要复习的内容。", EN: "🎉 Nothing to review today."},
+	"review.due":           {ZH: "到期 %s", EN: "due %s"},
+	"review.overdue":       {ZH: "逾期 %d 天", EN: "%d days overdue"},
+	"review.stats":         {ZH: "已复习 %d 次 · 难度系数 %.2f", EN: "reviewed %d times · ease %.2f"},
+	"review.quality_0":     {ZH: "完全忘了", EN: "Forgot completely"},
+	"review.quality_1":     {ZH: "看了才想起", EN: "Recalled after seeing it"},
+	"review.quality_2":     {ZH: "想起但有错", EN: "Recalled with mistakes"},
+	"review.quality_3":     {ZH: "费力想起", EN: "Recalled with effort"},
+	"review.quality_4":     {ZH: "稍有犹豫", EN: "Slight hesitation"},
+	"review.quality_5":     {ZH: "轻松记起", EN: "Easy"},
+	"review.upcoming":      {ZH: "📅 未来 7 天", EN: "📅 Next 7 days"},
+	"review.col_date":      {ZH: "日期", EN: "Date"},
+	"review.col_item":      {ZH: "内容", EN: "Item"},
+	"review.col_interval":  {ZH: "间隔", EN: "Interval"},
+	"review.days":          {ZH: "%d 天", EN: "%d days"},
+	"review.js.next":       {ZH: "✅ 下次复习: {due}（{interval} 天后）", EN: "✅ Next review: {due} (in {interval} days)"},
+	"review.link_exercise": {ZH: "📝 练习", EN: "📝 Exercise"},
+	"review.link_compare":  {ZH: "🔍 对比", EN: "🔍 Compare"},
+	"review.link_grade":    {ZH: "🧪 评分", EN: "🧪 Grade"},
+	"review.link_source":   {ZH: "🔧 源码", EN: "🔧 Source"},
+
+	// 要点测验，quiz.js. 开头的消息由页面脚本使用，{score} 等占位符由脚本替换
+	"quiz.title":      {ZH: "要点测验 - Go学习", EN: "Quizzes - Go Study"},
+	"quiz.heading":    {ZH: "📝 要点测验", EN: "📝 Quizzes"},
+	"quiz.intro":      {ZH: "题目来自 gobase 文件末尾的「学习要点总结」，结果保存在本地目录", EN: "Questions come from the key-point summaries at the end of the gobase files; results are saved locally in"},
+	"quiz.count":      {ZH: "%d 道题", EN: "%d questions"},
+	"quiz.page_title": {ZH: "%s 测验 - Go学习", EN: "%s Quiz - Go Study"},
+	"quiz.learner":    {ZH: "学习者", EN: "Learner"},
+	"quiz.empty":      {ZH: "这个模块还没有题目，可以在这个文件中添加", EN: "This module has no questions yet; add them in"},
+	"quiz.submit":     {ZH: "✅ 提交", EN: "✅ Submit"},
+	"quiz.js.history": {ZH: "已测验 {attempts} 次，最高 {best} 分", EN: "{attempts} attempts, best score {best}"},
+	"quiz.js.correct": {ZH: "✅ 正确", EN: "✅ Correct"},
+	"quiz.js.answer":  {ZH: "❌ 正确答案: ", EN: "❌ Correct answer: "},
+	"quiz.js.score":   {ZH: "得分 {score} · 答对 {correct} / {total}", EN: "Score {score} · {correct} / {total} correct"},
+
+	// 基准测试，bench.js. 开头的消息由页面脚本使用
+	"bench.title":            {ZH: "基准测试 - Go学习", EN: "Benchmarks - Go Study"},
+	"bench.heading":          {ZH: "⏱️ 基准测试", EN: "⏱️ Benchmarks"},
+	"bench.intro_scan":       {ZH: "扫描 gobase 和练习文件中的", EN: "Scans gobase and exercise files for"},
+	"bench.intro_run":        {ZH: "（包括写在字符串中的示例代码），用 testing.Benchmark 在临时目录中运行；结果保存在本地", EN: " (including example code inside strings) and runs them with testing.Benchmark in a temporary directory; results are saved locally in"},
+	"bench.intro_regression": {ZH: "，ns/op 或 B/op 比上一次增加超过 %.0f%%、或 allocs/op 增加时标记为回退。", EN: ". A benchmark is flagged as a regression when ns/op or B/op grows by more than %.0f%% or allocs/op grows."},
+	"bench.empty":            {ZH: "还没有找到基准测试函数。", EN: "No benchmark functions found yet."},
+	"bench.top_level":        {ZH: "顶层函数", EN: "top-level functions"},
+	"bench.embedded":         {ZH: "字符串中的示例代码", EN: "example code in a string"},
+	"bench.runs":             {ZH: "已运行 %d 次", EN: "%d runs"},
+	"bench.run":              {ZH: "▶️ 运行", EN: "▶️ Run"},
+	"bench.latest":           {ZH: "最近一次: %s · %s", EN: "Latest: %s · %s"},
+	"bench.col_name":         {ZH: "基准测试", EN: "Benchmark"},
+	"bench.col_n":            {ZH: "循环次数", EN: "Iterations"},
+	"bench.col_change":       {ZH: "与上次相比", EN: "Change since last run"},
+	"bench.bar_chart":        {ZH: "最近一次 ns/op", EN: "Latest ns/op"},
+	"bench.line_chart":       {ZH: "ns/op 历史趋势（最近 %d 次）", EN: "ns/op trend (last %d runs)"},
+	"bench.js.running":       {ZH: "⏳ 运行中…", EN: "⏳ Running…"},
+	"bench.unknown_suite":    {ZH: "没有这组基准测试: %s", EN: "No such benchmark suite: %s"},
+	"bench.run_failed":       {ZH: "基准测试运行失败（阶段 %s，退出码 %d）: %s", EN: "Benchmark failed (stage %s, exit code %d): %s"},
+	"bench.timed_out":        {ZH: "基准测试运行超时（阶段 %s）", EN: "Benchmark timed out (stage %s)"},
+	"bench.no_results":       {ZH: "基准测试运行失败: 没有得到任何结果，基准测试可能调用了 b.Fatal", EN: "Benchmark failed: no results, the benchmark may have called b.Fatal"},
+	"bench.parse_failed":     {ZH: "基准测试运行失败: %s", EN: "Benchmark failed: %s"},
+
+	// 练习评分
+	"grade.title":         {ZH: "Day %02d 评分", EN: "Day %02d Grade"},
+	"grade.exercise":      {ZH: "📝 练习", EN: "📝 Exercise"},
+	"grade.score":         {ZH: "得分 %.1f · 通过 %d / %d", EN: "Score %.1f · passed %d / %d"},
+	"grade.compile_error": {ZH: "编译错误", EN: "Compile errors"},
+	"grade.checks":        {ZH: "检查项", EN: "Checks"},
+	"grade.col_kind":      {ZH: "类别", EN: "Kind"},
+	"grade.col_name":      {ZH: "名称", EN: "Name"},
+	"grade.col_expected":  {ZH: "期望", EN: "Expected"},
+	"grade.col_actual":    {ZH: "实际", EN: "Actual"},
+	"grade.col_message":   {ZH: "说明", EN: "Details"},
+
+	// 输出对比
+	"compare.title":            {ZH: "Day %02d 输出对比", EN: "Day %02d Output Comparison"},
+	"compare.exercise":         {ZH: "📝 练习", EN: "📝 Exercise"},
+	"compare.reference":        {ZH: "🔧 参考", EN: "🔧 Reference"},
+	"compare.run_info":         {ZH: "（阶段 %s，退出码 %d）", EN: " (stage %s, exit code %d)"},
+	"compare.similarity":       {ZH: "相似度", EN: "similarity"},
+	"compare.stderr":           {ZH: "练习的错误输出", EN: "Exercise stderr"},
+	"compare.no_plan_day":      {ZH: "练习计划中没有 Day %02d", EN: "The study plan has no Day %02d"},
+	"compare.no_module":        {ZH: "练习计划中 Day %02d 没有对应的 gobase 模块", EN: "Day %02d of the study plan has no gobase module"},
+	"compare.no_exercise":      {ZH: "练习 %s 还不存在", EN: "Exercise %s does not exist yet"},
+	"compare.no_reference":     {ZH: "参考模块 gobase/%s 不存在", EN: "Reference module gobase/%s does not exist"},
+	"compare.exercise_failed":  {ZH: "练习未能成功运行，请先修复编译或运行错误", EN: "The exercise did not run successfully; fix the build or runtime errors first"},
+	"compare.reference_failed": {ZH: "参考模块未能成功运行，对比结果仅供参考", EN: "The reference module did not run successfully; treat the comparison as indicative only"},
+	"compare.identical":        {ZH: "输出与 gobase 参考模块一致", EN: "The output matches the gobase reference module"},
+	"compare.partial":          {ZH: "%.0f%% 的输出行与 gobase 参考模块一致", EN: "%.0f%% of the output lines match the gobase reference module"},
+	"compare.different":        {ZH: "输出与 gobase 参考模块差异较大", EN: "The output differs substantially from the gobase reference module"},
+	"compare.diff":             {ZH: "逐行对比（- 仅 gobase 参考输出，+ 仅练习输出）", EN: "Line diff (- only in the gobase reference output, + only in the exercise output)"},
+
+	// 接口请求错误
+	"request.method_not_allowed": {ZH: "不支持的请求方法", EN: "Method not allowed"},
+	"request.not_found":          {ZH: "没有这个地址: %s", EN: "No such path: %s"},
+	"request.invalid_param":      {ZH: "无效的路径参数 %s: %q", EN: "Invalid path parameter %s: %q"},
+	"request.invalid_query":      {ZH: "无效的查询参数 %s: %q", EN: "Invalid query parameter %s: %q"},
+	"request.unknown_field":      {ZH: "请求中有未知字段 %s", EN: "Unknown field %s in request"},
+	"request.too_large":          {ZH: "请求体超过 %d 字节", EN: "Request body exceeds %d bytes"},
+	"request.get_only":           {ZH: "只支持 GET 请求", EN: "Only GET requests are supported"},
+	"request.post_only":          {ZH: "只支持 POST 请求", EN: "Only POST requests are supported"},
+	"request.malformed":          {ZH: "请求格式错误", EN: "Malformed request"},
+	"request.internal":           {ZH: "服务器内部错误", EN: "Internal server error"},
+	"request.missing_query":      {ZH: "缺少查询参数 %s", EN: "Missing query parameter %s"},
+	"request.invalid_day":        {ZH: "缺少或无效的参数 day", EN: "Missing or invalid parameter day"},
+	"request.unknown_action":     {ZH: "未知的动作: %s", EN: "Unknown action: %s"},
+	"request.limit_positive":     {ZH: "limit 必须是正整数", EN: "limit must be a positive integer"},
+	"request.no_streaming":       {ZH: "服务器不支持流式响应", EN: "Streaming responses are not supported"},
+	"file.not_found":             {ZH: "文件不存在", EN: "File not found"},
+	"file.not_found_path":        {ZH: "文件不存在: %s", EN: "File not found: %s"},
+	"quiz.unknown_module":        {ZH: "没有这个测验模块: %s", EN: "No such quiz module: %s"},
+	"quiz.invalid_learner":       {ZH: "学习者名字只能包含字母、数字、下划线和连字符", EN: "Learner names may only contain letters, digits, underscores and hyphens"},
+	"quiz.save_failed":           {ZH: "保存测验结果失败: %s", EN: "Failed to save quiz result: %s"},
+
+	// 代码运行，编译中等状态消息按发起运行的请求语言翻译
+	"runner.busy":         {ZH: "运行队列已满，请稍后重试", EN: "The run queue is full, please try again later"},
+	"runner.building":     {ZH: "编译中: %s", EN: "Building: %s"},
+	"runner.no_isolation": {ZH: "无法在隔离网络的环境中运行程序", EN: "Cannot run the program with network isolation"},
+	"runner.running":      {ZH: "运行中", EN: "Running"},
+	"runner.output_limit": {ZH: "输出超过 %d 字节，已终止运行", EN: "Output exceeded %d bytes, run terminated"},
+
+	// 练习编辑、进度、评分和复习
+	"editor.outside_exercises":   {ZH: "只能编辑 exercises/ 下的 .go 文件", EN: "Only .go files under exercises/ can be edited"},
+	"editor.too_large":           {ZH: "文件过大，最大 %dKB", EN: "File too large, maximum %dKB"},
+	"progress.item_not_found":    {ZH: "任务不存在", EN: "Task not found"},
+	"progress.plan_unreadable":   {ZH: "读取学习计划失败", EN: "Failed to read the learning plan"},
+	"grading.no_spec":            {ZH: "Day %02d 还没有评分规格", EN: "Day %02d has no grading spec yet"},
+	"grading.no_exercise":        {ZH: "练习 %s 还不存在", EN: "Exercise %s does not exist yet"},
+	"grading.no_file":            {ZH: "练习 %s 中没有文件 %s", EN: "Exercise %s has no file %s"},
+	"grading.invalid_spec":       {ZH: "解析评分规格 %s 失败", EN: "Failed to parse grading spec %s"},
+	"grading.spec_day":           {ZH: "评分规格 %s 中的 day 为 %d", EN: "Grading spec %s has day %d"},
+	"grading.spec_case":          {ZH: "评分规格 %s 的第 %d 个用例缺少 name 或 call", EN: "Case %[2]d of grading spec %[1]s is missing name or call"},
+	"grading.syntax_error":       {ZH: "代码有语法错误", EN: "The code has syntax errors"},
+	"grading.no_function":        {ZH: "没有找到函数 %s", EN: "Function %s not found"},
+	"grading.signature_mismatch": {ZH: "函数签名不一致", EN: "Function signature does not match"},
+	"grading.no_type":            {ZH: "没有找到类型 %s", EN: "Type %s not found"},
+	"grading.type_mismatch":      {ZH: "类型定义不一致", EN: "Type definition does not match"},
+	"grading.build_failed":       {ZH: "练习编译失败", EN: "The exercise failed to build"},
+	"grading.run_failed":         {ZH: "程序未能正常结束", EN: "The program did not exit normally"},
+	"grading.stdout_missing":     {ZH: "输出中没有找到期望的内容", EN: "Expected text not found in the output"},
+	"grading.case_skipped":       {ZH: "%s 缺失或定义不一致，跳过该用例", EN: "%s is missing or does not match; case skipped"},
+	"grading.case_build_failed":  {ZH: "用例编译失败", EN: "The case failed to build"},
+	"grading.case_unfinished":    {ZH: "用例没有运行完成", EN: "The case did not finish"},
+	"grading.case_panicked":      {ZH: "运行时 panic", EN: "Panicked at runtime"},
+	"review.unknown_card":        {ZH: "没有这张复习卡片: %s", EN: "No such review card: %s"},
+	"review.invalid_quality":     {ZH: "回忆质量必须在 0~%d 之间", EN: "Recall quality must be between 0 and %d"},
+	"review.invalid_date":        {ZH: "无效的日期: %s", EN: "Invalid date: %s"},
+
+	// 新建练习
+	"scaffold.day_exists":   {ZH: "练习已存在: %s", EN: "Exercise already exists: %s"},
+	"scaffold.not_in_plan":  {ZH: "练习计划中没有 Day %02d", EN: "The study plan has no Day %02d"},
+	"scaffold.invalid_name": {ZH: "无效的文件名: %s（只能包含小写字母、数字和下划线）", EN: "Invalid file name: %s (lowercase letters, digits and underscores only)"},
+
+	// 填空练习
+	"blanks.day_not_empty":  {ZH: "%s 中已经有 Go 文件，填空练习是带 main 的完整程序，请写入一个还没有练习的天", EN: "%s already has Go files; blanks exercises are complete programs with their own main, so choose a day without exercises"},
+	"blanks.unknown_module": {ZH: "gobase 模块不存在: %s", EN: "No such gobase module: %s"},
+	"blanks.unknown_func":   {ZH: "模块中没有这个函数: %s", EN: "No such function in the module: %s"},
+	"blanks.invalid_day":    {ZH: "无效的天数: %d", EN: "Invalid day: %d"},
+	"blanks.invalid_level":  {ZH: "无效的难度: %s（可选 easy、medium、hard）", EN: "Invalid level: %s (easy, medium or hard)"},
+
+	// 代码片段
+	"snippet.title":          {ZH: "代码片段 %s - Go学习", EN: "Snippet %s - Go Study"},
+	"snippet.heading":        {ZH: "🔗 代码片段 %s", EN: "🔗 Snippet %s"},
+	"snippet.raw":            {ZH: "纯文本", EN: "Plain text"},
+	"snippet.created":        {ZH: "创建于 %s", EN: "Created %s"},
+	"snippet.expires":        {ZH: "过期时间 %s", EN: "Expires %s"},
+	"snippet.source":         {ZH: "来源", EN: "Source"},
+	"snippet.invalid_source": {ZH: "来源必须是 exercises/ 或 gobase/ 下的 .go 文件", EN: "The source must be a .go file under exercises/ or gobase/"},
+	"snippet.not_found":      {ZH: "代码片段不存在", EN: "Snippet not found"},
+	"snippet.expired":        {ZH: "代码片段已过期", EN: "Snippet has expired"},
+	"snippet.empty":          {ZH: "代码片段内容为空", EN: "Snippet is empty"},
+	"snippet.invalid":        {ZH: "代码片段必须是 UTF-8 文本", EN: "Snippets must be UTF-8 text"},
+	"snippet.too_large":      {ZH: "代码片段太大，上限 %d 字节", EN: "Snippet too large, limit %d bytes"},
+	"snippet.store_full":     {ZH: "代码片段存储空间已满: 已使用 %d 字节，上限 %d 字节", EN: "Snippet storage is full: %d bytes used, limit %d bytes"},
+	"snippet.invalid_ttl":    {ZH: "无效的过期时间: %s，上限 %s", EN: "Invalid expiry: %s, limit %s"},
+
+	// 请求参数校验，参数为字段名和规则参数
+	"validation.failed":   {ZH: "请求参数校验失败", EN: "Request validation failed"},
+	"validation.required": {ZH: "%[1]s 不能为空", EN: "%[1]s is required"},
+	"validation.min_len":  {ZH: "%[1]s 至少 %[2]s 个字符", EN: "%[1]s must be at least %[2]s characters long"},
+	"validation.max_len":  {ZH: "%[1]s 最多 %[2]s 个字符", EN: "%[1]s must be at most %[2]s characters long"},
+	"validation.min":      {ZH: "%[1]s 不能小于 %[2]s", EN: "%[1]s must be at least %[2]s"},
+	"validation.max":      {ZH: "%[1]s 不能大于 %[2]s", EN: "%[1]s must be at most %[2]s"},
+	"validation.email":    {ZH: "%[1]s 不是有效的邮箱地址", EN: "%[1]s must be a valid email address"},
+	"validation.oneof":    {ZH: "%[1]s 必须是 %[2]s 之一", EN: "%[1]s must be one of %[2]s"},
+
+	// 用户服务
+	"user.not_found":           {ZH: "用户不存在", EN: "User not found"},
+	"user.username_taken":      {ZH: "用户名已存在", EN: "Username already exists"},
+	"user.email_taken":         {ZH: "邮箱已存在", EN: "Email already exists"},
+	"user.invalid_credentials": {ZH: "用户名或密码错误", EN: "Invalid username or password"},
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go-web-api-study/internal/i18n"
+)
+
+// Locale 语言中间件
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.FromRequest(r)
+
+		// ?lang= 切换语言时写入 Cookie，之后的页面沿用
+		if l, ok := i18n.Parse(r.URL.Query().Get(i18n.QueryParam)); ok {
+			http.SetCookie(w, &http.Cookie{
+				Name:     i18n.CookieName,
+				Value:    string(l),
+				Path:     "/",
+				MaxAge:   365 * 24 * 60 * 60,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		// 响应内容随语言变化，缓存需要区分
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language, Cookie")
+
+		// 继续处理请求
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
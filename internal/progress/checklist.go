@@ -0,0 +1,97 @@
+package progress
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var checkboxPattern = regexp.MustCompile(`^(\s*[-*]\s+\[)([ xX])(\]\s+)(.+)$`)
+
+// Item Markdown 任务列表中的一项
+type Item struct {
+	ID      string `json:"id"`      // 由所在周、小节和内容计算出的稳定 ID
+	Line    int    `json:"line"`    // 所在行号（从 1 开始）
+	Week    string `json:"week"`    // 所在的 ### 标题
+	Section string `json:"section"` // 所在的 #### 标题
+	Text    string `json:"text"`
+	Done    bool   `json:"done"`
+}
+
+// ParseChecklist 解析 Markdown 中的 "- [x]"/"- [ ]" 任务列表
+func ParseChecklist(content []byte) []Item {
+	var (
+		items   []Item
+		week    string
+		section string
+	)
+	seen := make(map[string]int)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "#### "):
+			section = strings.TrimSpace(strings.TrimPrefix(line, "#### "))
+			continue
+		case strings.HasPrefix(line, "### "):
+			week = strings.TrimSpace(strings.TrimPrefix(line, "### "))
+			section = ""
+			continue
+		case strings.HasPrefix(line, "## "):
+			week, section = "", ""
+			continue
+		}
+
+		m := checkboxPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		text := strings.TrimSpace(m[4])
+		id := itemID(week, section, text)
+		// 同一小节中内容相同的任务加序号区分
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id = fmt.Sprintf("%s-%d", id, n)
+		}
+		items = append(items, Item{
+			ID:      id,
+			Line:    i + 1,
+			Week:    week,
+			Section: section,
+			Text:    text,
+			Done:    m[2] != " ",
+		})
+	}
+	return items
+}
+
+// SetChecked 把第 line 行的任务改为 done 状态，返回修改后的内容
+func SetChecked(content []byte, line int, done bool) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return nil, fmt.Errorf("行号超出范围: %d", line)
+	}
+
+	raw := lines[line-1]
+	cr := strings.HasSuffix(raw, "\r")
+	m := checkboxPattern.FindStringSubmatch(strings.TrimSuffix(raw, "\r"))
+	if m == nil {
+		return nil, fmt.Errorf("第 %d 行不是任务列表项", line)
+	}
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	lines[line-1] = m[1] + mark + m[3] + m[4]
+	if cr {
+		lines[line-1] += "\r"
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// itemID 计算任务的稳定 ID，行号变化不影响 ID
+func itemID(week, section, text string) string {
+	sum := sha1.Sum([]byte(week + "\x00" + section + "\x00" + text))
+	return hex.EncodeToString(sum[:])[:10]
+}
@@ -0,0 +1,104 @@
+package progress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecklist(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Item
+	}{
+		{name: "empty", content: "", want: nil},
+		{
+			name:    "headings and marks",
+			content: "## 计划\n### 第一周\n- [x] 变量\n#### 练习\n- [ ] 切片\n* [X] map\n",
+			want: []Item{
+				{ID: itemID("第一周", "", "变量"), Line: 3, Week: "第一周", Text: "变量", Done: true},
+				{ID: itemID("第一周", "练习", "切片"), Line: 5, Week: "第一周", Section: "练习", Text: "切片"},
+				{ID: itemID("第一周", "练习", "map"), Line: 6, Week: "第一周", Section: "练习", Text: "map", Done: true},
+			},
+		},
+		{
+			name:    "new week resets section",
+			content: "### 第一周\n#### 练习\n### 第二周\n- [ ] 并发\n",
+			want:    []Item{{ID: itemID("第二周", "", "并发"), Line: 4, Week: "第二周", Text: "并发"}},
+		},
+		{
+			name:    "level two heading resets week",
+			content: "### 第一周\n## 附录\n- [ ] 阅读\n",
+			want:    []Item{{ID: itemID("", "", "阅读"), Line: 3, Text: "阅读"}},
+		},
+		{
+			name:    "duplicate text gets a suffix",
+			content: "- [ ] 复习\n- [x] 复习\n",
+			want: []Item{
+				{ID: itemID("", "", "复习"), Line: 1, Text: "复习"},
+				{ID: itemID("", "", "复习") + "-2", Line: 2, Text: "复习", Done: true},
+			},
+		},
+		{
+			name:    "crlf, indentation and trailing spaces",
+			content: "  - [x] 嵌套  \r\n",
+			want:    []Item{{ID: itemID("", "", "嵌套"), Line: 1, Text: "嵌套", Done: true}},
+		},
+		{
+			name:    "not checkboxes",
+			content: "- 普通列表\n- [] 缺少空格\n- [y] 未知标记\n- [ ]\n",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseChecklist([]byte(tt.content)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseChecklist() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemIDIgnoresLineNumber(t *testing.T) {
+	a := ParseChecklist([]byte("### 第一周\n- [ ] 变量\n"))
+	b := ParseChecklist([]byte("### 第一周\n\n说明\n- [x] 变量\n"))
+	if len(a) != 1 || len(b) != 1 || a[0].ID != b[0].ID {
+		t.Errorf("IDs differ: %+v vs %+v", a, b)
+	}
+}
+
+func TestSetChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		done    bool
+		want    string
+		wantErr bool
+	}{
+		{name: "check", content: "# 标题\n- [ ] 变量\n", line: 2, done: true, want: "# 标题\n- [x] 变量\n"},
+		{name: "uncheck", content: "- [X] 变量", line: 1, done: false, want: "- [ ] 变量"},
+		{name: "already checked", content: "- [x] 变量", line: 1, done: true, want: "- [x] 变量"},
+		{name: "keeps indentation and crlf", content: "a\r\n  * [ ]  嵌套\r\nb", line: 2, done: true, want: "a\r\n  * [x]  嵌套\r\nb"},
+		{name: "line zero", content: "- [ ] a", line: 0, wantErr: true},
+		{name: "line past end", content: "- [ ] a", line: 2, wantErr: true},
+		{name: "not a checkbox", content: "# 标题", line: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetChecked([]byte(tt.content), tt.line, tt.done)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SetChecked() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetChecked() = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("SetChecked() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// maxToggleBody 切换任务请求体的大小上限
+const maxToggleBody = 4096
+
+// ToggleRequest 切换任务状态的请求
+type ToggleRequest struct {
+	ID   string `json:"id"`
+	Done bool   `json:"done"`
+}
+
+// APIHandler /api/v1/progress：GET 返回进度汇总，POST 切换任务并写回 docs/learning_plan.md
+func (t *Tracker) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			summary, err := t.Summary(r.Context())
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, summary)
+
+		case http.MethodPost:
+			var req ToggleRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxToggleBody)).Decode(&req); err != nil || req.ID == "" {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			item, err := t.Toggle(req.ID, req.Done)
+			if err != nil {
+				// 任务不存在为 404，读写学习计划失败为 500
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, item)
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// PageHandler /progress 学习进度面板
+func (t *Tracker) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s, err := t.Summary(r.Context())
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+		pages.Render(w, r, "progress", s)
+	}
+}
@@ -0,0 +1,286 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/fsutil"
+	"go-web-api-study/internal/runner"
+)
+
+// 进度错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrItemNotFound 要切换的任务不存在
+	ErrItemNotFound = apperr.New(apperr.NotFound, "plan_item_not_found", "progress.item_not_found")
+	// ErrPlanUnreadable 读取学习计划文档失败，原因只写入日志
+	ErrPlanUnreadable = apperr.New(apperr.Internal, "plan_unreadable", "progress.plan_unreadable")
+)
+
+// PlanFile 学习计划文档相对仓库根目录的路径
+const PlanFile = "docs/learning_plan.md"
+
+// WeekProgress 一周的完成情况
+type WeekProgress struct {
+	Title   string  `json:"title"`
+	Total   int     `json:"total"`
+	Done    int     `json:"done"`
+	Percent float64 `json:"percent"`
+}
+
+// DayStatus 42 天练习计划中某一天的状态
+type DayStatus struct {
+	catalog.PlanDay
+	Group    string `json:"group"`
+	Route    string `json:"route,omitempty"` // 练习存在时指向第一个文件
+	Exists   bool   `json:"exists"`          // exercises/dayNN 下有 .go 文件
+	Compiles bool   `json:"compiles"`        // 所有文件都能编译通过
+	Error    string `json:"error,omitempty"` // 编译错误输出
+	Pending  bool   `json:"pending"`         // 运行队列繁忙，还没有编译结果，稍后刷新
+}
+
+// Done 该天是否视为完成：练习存在且能编译
+func (d DayStatus) Done() bool {
+	return d.Exists && d.Compiles
+}
+
+// Summary 进度汇总
+type Summary struct {
+	Items         []Item         `json:"items"`          // docs/learning_plan.md 中的任务
+	PlanWeeks     []WeekProgress `json:"plan_weeks"`     // 按学习计划周统计的任务完成率
+	Days          []DayStatus    `json:"days"`           // exercises/README.md 中的 42 天练习
+	DayWeeks      []WeekProgress `json:"day_weeks"`      // 按练习计划周统计的完成率
+	Percent       float64        `json:"percent"`        // 任务和练习合计的完成率
+	CurrentStreak int            `json:"current_streak"` // 截至最近完成的一天，连续完成的练习天数
+	LongestStreak int            `json:"longest_streak"` // 最长的连续完成天数
+	Next          *DayStatus     `json:"next,omitempty"` // 推荐的下一天：第一个未完成的练习
+}
+
+// buildCache 按文件修改时间缓存的编译结果
+type buildCache struct {
+	modTime time.Time
+	size    int64
+	ok      bool
+	output  string
+}
+
+// Tracker 学习进度跟踪器
+type Tracker struct {
+	cat *catalog.Catalog
+	run *runner.Runner
+
+	mu     sync.Mutex // 保护学习计划文档的读改写
+	cacheM sync.Mutex
+	cache  map[string]buildCache
+}
+
+// NewTracker 创建进度跟踪器
+func NewTracker(cat *catalog.Catalog, run *runner.Runner) *Tracker {
+	return &Tracker{cat: cat, run: run, cache: make(map[string]buildCache)}
+}
+
+// planPath 返回学习计划文档在磁盘上的路径
+func (t *Tracker) planPath() string {
+	return filepath.Join(t.cat.Root(), filepath.FromSlash(PlanFile))
+}
+
+// Summary 汇总学习计划任务和每日练习的完成情况
+func (t *Tracker) Summary(ctx context.Context) (*Summary, error) {
+	content, err := os.ReadFile(t.planPath())
+	if err != nil {
+		return nil, ErrPlanUnreadable.Wrap(err)
+	}
+
+	s := &Summary{Items: ParseChecklist(content)}
+	s.PlanWeeks = weekProgress(s.Items, func(it Item) (string, bool) { return it.Week, it.Done })
+
+	busy := false // 遇到一次运行队列已满后，其余未缓存的文件不再排队等待
+	for _, d := range t.cat.Plan() {
+		status, err := t.dayStatus(ctx, d, &busy)
+		if err != nil {
+			return nil, err
+		}
+		s.Days = append(s.Days, status)
+	}
+	s.DayWeeks = weekProgress(s.Days, func(d DayStatus) (string, bool) { return d.WeekTitle, d.Done() })
+
+	var done, total int
+	for _, it := range s.Items {
+		total++
+		if it.Done {
+			done++
+		}
+	}
+	for i, d := range s.Days {
+		total++
+		if d.Done() {
+			done++
+		} else if s.Next == nil && !d.Pending {
+			s.Next = &s.Days[i]
+		}
+	}
+	s.Percent = percent(done, total)
+	s.CurrentStreak, s.LongestStreak = streaks(s.Days)
+	return s, nil
+}
+
+// Toggle 把任务 id 设置为 done 状态并原子地写回学习计划文档
+func (t *Tracker) Toggle(id string, done bool) (Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path := t.planPath()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Item{}, ErrPlanUnreadable.Wrap(err)
+	}
+
+	for _, it := range ParseChecklist(content) {
+		if it.ID != id {
+			continue
+		}
+		if it.Done == done {
+			return it, nil
+		}
+		updated, err := SetChecked(content, it.Line, done)
+		if err != nil {
+			return Item{}, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return Item{}, err
+		}
+		if err := fsutil.WriteFileAtomic(path, updated, info.Mode().Perm()); err != nil {
+			return Item{}, err
+		}
+		it.Done = done
+		return it, nil
+	}
+	return Item{}, ErrItemNotFound
+}
+
+// dayStatus 检查某一天的练习是否存在以及能否编译
+func (t *Tracker) dayStatus(ctx context.Context, d catalog.PlanDay, busy *bool) (DayStatus, error) {
+	status := DayStatus{PlanDay: d, Group: d.Group()}
+	files := t.cat.Day(status.Group)
+	if len(files) == 0 {
+		return status, nil
+	}
+
+	status.Exists = true
+	status.Route = files[0].Route
+	status.Compiles = true
+	for _, f := range files {
+		ok, output, err := t.compiles(ctx, filepath.Join(t.cat.Root(), f.Path), !*busy)
+		if errors.Is(err, runner.ErrBusy) {
+			// 运行队列被其他请求占满时不让整个汇总失败，这一天标记为待检查
+			*busy = true
+			status.Compiles, status.Pending = false, true
+			continue
+		}
+		if err != nil {
+			return status, err
+		}
+		if !ok {
+			status.Compiles = false
+			status.Error = strings.TrimSpace(status.Error + "\n" + output)
+		}
+	}
+	return status, nil
+}
+
+// compiles 编译检查单个文件，文件未修改时直接使用缓存结果；运行队列繁忙或 check 为 false 时
+// 如果有旧的结果就先用旧结果，没有时返回 runner.ErrBusy
+func (t *Tracker) compiles(ctx context.Context, filename string, check bool) (bool, string, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return false, "", err
+	}
+
+	t.cacheM.Lock()
+	cached, ok := t.cache[filename]
+	t.cacheM.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.ok, cached.output, nil
+	}
+	if !check {
+		if ok {
+			return cached.ok, cached.output, nil
+		}
+		return false, "", runner.ErrBusy
+	}
+
+	var stderr strings.Builder
+	result, err := t.run.Check(ctx, filename, func(ev runner.Event) {
+		if ev.Stream == runner.StreamStderr {
+			stderr.WriteString(ev.Data)
+		}
+	})
+	if errors.Is(err, runner.ErrBusy) && ok {
+		return cached.ok, cached.output, nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	entry := buildCache{modTime: info.ModTime(), size: info.Size(), ok: result.ExitCode == 0 && !result.TimedOut, output: stderr.String()}
+	t.cacheM.Lock()
+	t.cache[filename] = entry
+	t.cacheM.Unlock()
+	return entry.ok, entry.output, nil
+}
+
+// weekProgress 按周分组统计完成率，保持周的出现顺序
+func weekProgress[T any](items []T, key func(T) (week string, done bool)) []WeekProgress {
+	var weeks []WeekProgress
+	index := make(map[string]int)
+	for _, it := range items {
+		week, done := key(it)
+		if week == "" {
+			continue
+		}
+		i, ok := index[week]
+		if !ok {
+			i = len(weeks)
+			index[week] = i
+			weeks = append(weeks, WeekProgress{Title: week})
+		}
+		weeks[i].Total++
+		if done {
+			weeks[i].Done++
+		}
+	}
+	for i := range weeks {
+		weeks[i].Percent = percent(weeks[i].Done, weeks[i].Total)
+	}
+	return weeks
+}
+
+// streaks 计算连续完成的练习天数：current 为以最近完成的一天结尾的连续天数
+func streaks(days []DayStatus) (current, longest int) {
+	run := 0
+	for _, d := range days {
+		if d.Done() {
+			run++
+			current = run
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return current, longest
+}
+
+// percent 计算百分比，保留一位小数
+func percent(done, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(done*1000/total) / 10
+}
@@ -0,0 +1,153 @@
+package quiz
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// SubmitRequest 提交测验的请求
+type SubmitRequest struct {
+	Module  string            `json:"module"`
+	Learner string            `json:"learner"` // 为空时为 default
+	Answers map[string]string `json:"answers"` // 题目 ID 到答案，单选题为选项字母
+}
+
+// APIHandler /api/v1/quiz：GET 列出模块，GET ?module= 返回题目，POST SubmitRequest 评分并保存结果
+func (s *Service) APIHandler(store *Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			module := r.URL.Query().Get("module")
+			if module == "" {
+				modules, err := s.Modules()
+				if err != nil {
+					handler.WriteError(w, r, err)
+					return
+				}
+				handler.SuccessResponse(w, modules)
+				return
+			}
+			q, err := s.Get(module)
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, q)
+
+		case http.MethodPost:
+			var req SubmitRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil || req.Module == "" {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			if req.Learner == "" {
+				req.Learner = DefaultLearner
+			}
+			if !ValidLearner(req.Learner) {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "quiz.invalid_learner")
+				return
+			}
+			result, err := s.Grade(req.Module, req.Learner, req.Answers)
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			if err := store.Save(result); err != nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusInternalServerError, "quiz.save_failed", err.Error())
+				return
+			}
+			handler.SuccessResponse(w, result)
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// ResultsHandler GET /api/v1/quiz/results?learner= 返回学习者的测验记录
+func (s *Store) ResultsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.get_only")
+			return
+		}
+		learner := r.URL.Query().Get("learner")
+		if learner == "" {
+			learner = DefaultLearner
+		}
+		if !ValidLearner(learner) {
+			handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "quiz.invalid_learner")
+			return
+		}
+		h, err := s.History(learner)
+		if err != nil {
+			handler.WriteError(w, r, err)
+			return
+		}
+		handler.SuccessResponse(w, h)
+	}
+}
+
+// IndexHandler /quiz 列出有学习要点总结的模块
+func (s *Service) IndexHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		modules, err := s.Modules()
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+		pages.Render(w, r, "quiz-index", map[string]interface{}{
+			"Modules": modules,
+			"Dir":     ResultsDir,
+		})
+	}
+}
+
+// choice 单选题的一个选项
+type choice struct {
+	Letter string
+	Text   string
+}
+
+// pageQuestion 测验页面上的一道题，单选题带选项字母
+type pageQuestion struct {
+	PublicQuestion
+	Choices []choice
+}
+
+// PageHandler /quiz/05_http_basics 模块测验页面
+func (s *Service) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		module := strings.Trim(strings.TrimPrefix(r.URL.Path, "/quiz/"), "/")
+		if module == "" {
+			s.IndexHandler(pages)(w, r)
+			return
+		}
+		q, err := s.Get(module)
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+
+		questions := make([]pageQuestion, 0, len(q.Questions))
+		for _, question := range q.Questions {
+			pq := pageQuestion{PublicQuestion: question}
+			if question.Type == TypeChoice {
+				for j, opt := range question.Options {
+					pq.Choices = append(pq.Choices, choice{Letter: string(rune('A' + j)), Text: opt})
+				}
+			}
+			questions = append(questions, pq)
+		}
+		pages.Render(w, r, "quiz", map[string]interface{}{
+			"Quiz":      q,
+			"Questions": questions,
+			"Learner":   DefaultLearner,
+			"Bank":      BankFile,
+		})
+	}
+}
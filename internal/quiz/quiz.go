@@ -0,0 +1,348 @@
+package quiz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/symbols"
+)
+
+// BankFile 题库文件，相对仓库根目录
+const BankFile = "gobase/quiz.json"
+
+// 题型
+const (
+	TypeChoice = "choice" // 单选题，Answer 为选项字母
+	TypeFill   = "fill"   // 填空题，Accept 中任一答案即为正确
+)
+
+// ErrUnknownModule 模块不存在或没有学习要点总结，消息按请求语言翻译（见 internal/apperr）
+var ErrUnknownModule = apperr.New(apperr.NotFound, "quiz_module_not_found", "quiz.unknown_module")
+
+// Question 题库中的一道题
+type Question struct {
+	ID          string   `json:"id"`
+	Point       string   `json:"point"` // 对应的要点 ID，如 4.2，或只写主题编号
+	Type        string   `json:"type"`
+	Prompt      string   `json:"prompt"`
+	Options     []string `json:"options,omitempty"`
+	Answer      string   `json:"answer,omitempty"` // 单选题的正确选项，如 B
+	Accept      []string `json:"accept,omitempty"` // 填空题可接受的答案，比较时忽略大小写和空白
+	Explanation string   `json:"explanation"`
+	Symbol      string   `json:"symbol,omitempty"` // 解析指向的函数或类型，为空时指向要点所在行
+}
+
+// Bank 题库：gobase 文件名到题目列表
+type Bank struct {
+	Modules map[string][]Question `json:"modules"`
+}
+
+// Source 解析指向的源码位置
+type Source struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	URL  string `json:"url"`
+}
+
+// PublicQuestion 发给学习者的题目，不含答案
+type PublicQuestion struct {
+	ID        string   `json:"id"`
+	Point     string   `json:"point"`
+	PointText string   `json:"point_text"`
+	Topic     string   `json:"topic"`
+	Type      string   `json:"type"`
+	Prompt    string   `json:"prompt"`
+	Options   []string `json:"options,omitempty"`
+}
+
+// Quiz 一个模块的测验
+type Quiz struct {
+	Module    string           `json:"module"`
+	Title     string           `json:"title"`
+	Route     string           `json:"route"`
+	Summary   *Summary         `json:"summary"`
+	Count     int              `json:"count"` // 题目数
+	Questions []PublicQuestion `json:"questions,omitempty"`
+}
+
+// Item 一道题的评分结果
+type Item struct {
+	Question    string `json:"question"`
+	Prompt      string `json:"prompt"`
+	Given       string `json:"given"`
+	Correct     bool   `json:"correct"`
+	Answer      string `json:"answer"` // 正确答案的展示形式
+	Explanation string `json:"explanation"`
+	Source      Source `json:"source"`
+}
+
+// Result 一次测验的结果
+type Result struct {
+	Module  string    `json:"module"`
+	Learner string    `json:"learner"`
+	Time    time.Time `json:"time"`
+	Correct int       `json:"correct"`
+	Total   int       `json:"total"`
+	Score   float64   `json:"score"` // 0~100
+	Items   []Item    `json:"items"`
+}
+
+// Service 按 gobase 模块出题和评分，题库按修改时间缓存
+type Service struct {
+	cat *catalog.Catalog
+	idx *symbols.Index
+
+	mu      sync.Mutex
+	bank    *Bank
+	modTime time.Time
+}
+
+// NewService 创建测验服务
+func NewService(cat *catalog.Catalog, idx *symbols.Index) *Service {
+	return &Service{cat: cat, idx: idx}
+}
+
+// loadBank 读取题库，文件未变化时使用缓存；文件不存在时为空题库
+func (s *Service) loadBank() (*Bank, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filename := filepath.Join(s.cat.Root(), filepath.FromSlash(BankFile))
+	info, err := os.Stat(filename)
+	if os.IsNotExist(err) {
+		return &Bank{Modules: map[string][]Question{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.bank != nil && info.ModTime().Equal(s.modTime) {
+		return s.bank, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var bank Bank
+	if err := json.Unmarshal(data, &bank); err != nil {
+		return nil, fmt.Errorf("解析题库 %s 失败: %w", BankFile, err)
+	}
+	s.bank, s.modTime = &bank, info.ModTime()
+	return s.bank, nil
+}
+
+// Modules 列出有学习要点总结的 gobase 模块及其题目数
+func (s *Service) Modules() ([]Quiz, error) {
+	bank, err := s.loadBank()
+	if err != nil {
+		return nil, err
+	}
+	var quizzes []Quiz
+	for _, e := range s.cat.Entries(catalog.KindGobase) {
+		if !strings.HasSuffix(e.Path, ".go") {
+			continue
+		}
+		summary, err := s.summary(e)
+		if errors.Is(err, ErrNoSummary) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		module := filepath.Base(e.Path)
+		quizzes = append(quizzes, Quiz{Module: module, Title: e.Title, Route: e.Route, Summary: summary, Count: len(bank.Modules[module])})
+	}
+	return quizzes, nil
+}
+
+// Get 返回模块的要点总结和题目（不含答案）
+func (s *Service) Get(module string) (*Quiz, error) {
+	e, summary, questions, err := s.load(module)
+	if err != nil {
+		return nil, err
+	}
+	q := &Quiz{Module: filepath.Base(e.Path), Title: e.Title, Route: e.Route, Summary: summary, Count: len(questions), Questions: []PublicQuestion{}}
+	for _, question := range questions {
+		point, _ := summary.Point(question.Point)
+		q.Questions = append(q.Questions, PublicQuestion{
+			ID:        question.ID,
+			Point:     question.Point,
+			PointText: point.Text,
+			Topic:     topicTitle(summary, question.Point),
+			Type:      question.Type,
+			Prompt:    question.Prompt,
+			Options:   question.Options,
+		})
+	}
+	return q, nil
+}
+
+// Grade 为一次作答评分，answers 为题目 ID 到答案的映射，未作答的题判为错误
+func (s *Service) Grade(module, learner string, answers map[string]string) (*Result, error) {
+	e, summary, questions, err := s.load(module)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Module: filepath.Base(e.Path), Learner: learner, Time: time.Now(), Items: []Item{}}
+	for _, q := range questions {
+		given := strings.TrimSpace(answers[q.ID])
+		item := Item{
+			Question:    q.ID,
+			Prompt:      q.Prompt,
+			Given:       given,
+			Correct:     q.check(given),
+			Answer:      q.display(),
+			Explanation: q.Explanation,
+			Source:      s.source(e, summary, q),
+		}
+		result.Items = append(result.Items, item)
+		result.Total++
+		if item.Correct {
+			result.Correct++
+		}
+	}
+	if result.Total > 0 {
+		result.Score = float64(result.Correct*1000/result.Total) / 10
+	}
+	return result, nil
+}
+
+// load 查找模块、解析要点总结并校验题目
+func (s *Service) load(module string) (catalog.Entry, *Summary, []Question, error) {
+	module = strings.TrimSuffix(strings.TrimPrefix(module, "gobase/"), ".go") + ".go"
+	e, ok := s.cat.LookupPath("gobase/" + module)
+	if !ok || e.Kind != catalog.KindGobase {
+		return catalog.Entry{}, nil, nil, ErrUnknownModule.With(module)
+	}
+	summary, err := s.summary(e)
+	if errors.Is(err, ErrNoSummary) {
+		return catalog.Entry{}, nil, nil, ErrUnknownModule.With(module).Wrap(err)
+	}
+	if err != nil {
+		return catalog.Entry{}, nil, nil, err
+	}
+	bank, err := s.loadBank()
+	if err != nil {
+		return catalog.Entry{}, nil, nil, err
+	}
+	questions := bank.Modules[module]
+	for _, q := range questions {
+		if err := q.validate(summary); err != nil {
+			return catalog.Entry{}, nil, nil, fmt.Errorf("题库 %s 中 %s 的题目 %s: %w", BankFile, module, q.ID, err)
+		}
+	}
+	return e, summary, questions, nil
+}
+
+// summary 读取并解析模块的学习要点总结
+func (s *Service) summary(e catalog.Entry) (*Summary, error) {
+	src, err := os.ReadFile(filepath.Join(s.cat.Root(), filepath.FromSlash(e.Path)))
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(e.Path, src)
+}
+
+// source 解析指向的源码位置：题目指定了符号时指向符号定义，否则指向要点所在行
+func (s *Service) source(e catalog.Entry, summary *Summary, q Question) Source {
+	line := 0
+	if q.Symbol != "" && s.idx != nil {
+		s.idx.Refresh()
+		for _, sym := range s.idx.Outline(e.Path) {
+			if sym.Name == q.Symbol || sym.QualifiedName() == q.Symbol {
+				line = sym.Line
+				break
+			}
+		}
+	}
+	if line == 0 {
+		point, _ := summary.Point(q.Point)
+		line = point.Line
+	}
+	return Source{Path: e.Path, Line: line, URL: fmt.Sprintf("%s#L%d", e.Route, line)}
+}
+
+// validate 检查题目的题型、选项和对应的要点
+func (q Question) validate(summary *Summary) error {
+	if q.ID == "" || q.Prompt == "" {
+		return errors.New("缺少 id 或 prompt")
+	}
+	if _, ok := summary.Point(q.Point); !ok {
+		return fmt.Errorf("要点 %q 不在学习要点总结中", q.Point)
+	}
+	switch q.Type {
+	case TypeChoice:
+		i := optionIndex(q.Answer)
+		if len(q.Options) < 2 || i < 0 || i >= len(q.Options) {
+			return errors.New("单选题至少需要两个选项，answer 为其中之一的字母")
+		}
+	case TypeFill:
+		if len(q.Accept) == 0 {
+			return errors.New("填空题缺少 accept")
+		}
+	default:
+		return fmt.Errorf("未知的题型 %q", q.Type)
+	}
+	return nil
+}
+
+// check 判断答案是否正确
+func (q Question) check(given string) bool {
+	if given == "" {
+		return false
+	}
+	if q.Type == TypeChoice {
+		return strings.EqualFold(given, q.Answer)
+	}
+	for _, a := range q.Accept {
+		if normalize(a) == normalize(given) {
+			return true
+		}
+	}
+	return false
+}
+
+// display 正确答案的展示形式
+func (q Question) display() string {
+	if q.Type == TypeChoice {
+		letter := strings.ToUpper(q.Answer)
+		return letter + ". " + q.Options[optionIndex(letter)]
+	}
+	return strings.Join(q.Accept, " / ")
+}
+
+// optionIndex 把选项字母 A、B、C…… 转换为下标
+func optionIndex(letter string) int {
+	if len(letter) != 1 {
+		return -1
+	}
+	c := letter[0] | 0x20 // 转小写
+	if c < 'a' || c > 'z' {
+		return -1
+	}
+	return int(c - 'a')
+}
+
+// normalize 忽略大小写和空白
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
+
+// topicTitle 返回要点所属主题的标题
+func topicTitle(summary *Summary, point string) string {
+	id, _, _ := strings.Cut(point, ".")
+	for _, t := range summary.Topics {
+		if t.ID == id {
+			return t.Title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package quiz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"go-web-api-study/internal/fsutil"
+)
+
+// ResultsDir 测验结果所在目录，相对仓库根目录，每个学习者一个 JSON 文件
+const ResultsDir = ".study/quiz"
+
+// DefaultLearner 未指定学习者时使用的名字
+const DefaultLearner = "default"
+
+var learnerPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ValidLearner 学习者名字只允许字母、数字、下划线和连字符，用作文件名
+func ValidLearner(name string) bool {
+	return learnerPattern.MatchString(name)
+}
+
+// ModuleStats 学习者在一个模块上的成绩
+type ModuleStats struct {
+	Module   string  `json:"module"`
+	Attempts int     `json:"attempts"`
+	Best     float64 `json:"best"`
+	Last     float64 `json:"last"`
+}
+
+// History 学习者的全部测验记录
+type History struct {
+	Learner string        `json:"learner"`
+	Modules []ModuleStats `json:"modules"`
+	Results []Result      `json:"results"` // 按时间先后
+}
+
+// Store 本地测验结果存储
+type Store struct {
+	root string
+	mu   sync.Mutex
+}
+
+// NewStore 创建结果存储，root 为仓库根目录
+func NewStore(root string) *Store {
+	return &Store{root: root}
+}
+
+// path 学习者结果文件的路径
+func (s *Store) path(learner string) string {
+	return filepath.Join(s.root, filepath.FromSlash(ResultsDir), learner+".json")
+}
+
+// Save 追加一次测验结果
+func (s *Store) Save(result *Result) error {
+	if !ValidLearner(result.Learner) {
+		return fmt.Errorf("无效的学习者名字: %s", result.Learner)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.read(result.Learner)
+	if err != nil {
+		return err
+	}
+	results = append(results, *result)
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := s.path(result.Learner)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(filename, data, 0o644)
+}
+
+// History 读取学习者的测验记录并按模块统计
+func (s *Store) History(learner string) (*History, error) {
+	if !ValidLearner(learner) {
+		return nil, fmt.Errorf("无效的学习者名字: %s", learner)
+	}
+	s.mu.Lock()
+	results, err := s.read(learner)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &History{Learner: learner, Modules: []ModuleStats{}, Results: results}
+	index := make(map[string]int)
+	for _, r := range results {
+		i, ok := index[r.Module]
+		if !ok {
+			i = len(h.Modules)
+			index[r.Module] = i
+			h.Modules = append(h.Modules, ModuleStats{Module: r.Module})
+		}
+		m := &h.Modules[i]
+		m.Attempts++
+		m.Best = max(m.Best, r.Score)
+		m.Last = r.Score
+	}
+	return h, nil
+}
+
+// read 读取结果文件，不存在时为空
+func (s *Store) read(learner string) ([]Result, error) {
+	data, err := os.ReadFile(s.path(learner))
+	if os.IsNotExist(err) {
+		return []Result{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("解析测验记录失败: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,116 @@
+package quiz
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoSummary 模块中没有“学习要点总结”注释块
+var ErrNoSummary = errors.New("模块中没有学习要点总结")
+
+// 注释块中的小节标题
+const (
+	summaryHeading  = "学习要点总结"
+	practiceHeading = "实践建议"
+)
+
+var (
+	topicLine = regexp.MustCompile(`^(\d+)\.\s*(.+)$`)
+	pointLine = regexp.MustCompile(`^-\s*(.+)$`)
+)
+
+// Point 一条要点
+type Point struct {
+	ID   string `json:"id"` // 主题编号.要点序号，如 4.2
+	Text string `json:"text"`
+	Line int    `json:"line"`
+}
+
+// Topic 一个编号主题及其要点
+type Topic struct {
+	ID     string  `json:"id"` // 主题编号，如 4
+	Title  string  `json:"title"`
+	Line   int     `json:"line"`
+	Points []Point `json:"points"`
+}
+
+// Summary gobase 文件末尾的学习要点总结
+type Summary struct {
+	Path     string  `json:"path"`
+	Topics   []Topic `json:"topics"`
+	Practice []Point `json:"practice"` // 实践建议，ID 为 p1、p2……
+}
+
+// Point 按 ID 查找要点，ID 只写主题编号时返回主题本身
+func (s *Summary) Point(id string) (Point, bool) {
+	for _, t := range s.Topics {
+		if t.ID == id {
+			return Point{ID: t.ID, Text: t.Title, Line: t.Line}, true
+		}
+		for _, p := range t.Points {
+			if p.ID == id {
+				return p, true
+			}
+		}
+	}
+	for _, p := range s.Practice {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// ParseSummary 解析源码中以“学习要点总结：”开头、“实践建议：”结尾的块注释
+func ParseSummary(path string, src []byte) (*Summary, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, "/*") || !strings.Contains(c.Text, summaryHeading) {
+				continue
+			}
+			return parseBlock(path, c.Text, fset.Position(c.Pos()).Line), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoSummary, path)
+}
+
+// parseBlock 逐行解析注释文本，first 为注释开始的行号
+func parseBlock(path, text string, first int) *Summary {
+	s := &Summary{Path: path, Topics: []Topic{}, Practice: []Point{}}
+	practice := false
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(raw, "/*"), "*/"))
+		lineNo := first + i
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, practiceHeading):
+			practice = true
+		case strings.HasPrefix(line, summaryHeading):
+		case practice:
+			if m := pointLine.FindStringSubmatch(line); m != nil {
+				s.Practice = append(s.Practice, Point{ID: "p" + strconv.Itoa(len(s.Practice)+1), Text: m[1], Line: lineNo})
+			}
+		default:
+			if m := topicLine.FindStringSubmatch(line); m != nil {
+				s.Topics = append(s.Topics, Topic{ID: m[1], Title: m[2], Line: lineNo, Points: []Point{}})
+				continue
+			}
+			if m := pointLine.FindStringSubmatch(line); m != nil && len(s.Topics) > 0 {
+				t := &s.Topics[len(s.Topics)-1]
+				t.Points = append(t.Points, Point{ID: t.ID + "." + strconv.Itoa(len(t.Points)+1), Text: m[1], Line: lineNo})
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package review
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// ReviewRequest 记录复习的请求
+type ReviewRequest struct {
+	ID      string `json:"id"`
+	Quality *int   `json:"quality"` // 0 完全忘记 ~ 5 轻松记起
+	Date    string `json:"date"`    // 复习日期，默认今天
+}
+
+// APIHandler /api/v1/review：GET ?date= 返回复习安排，POST ReviewRequest 记录一次复习
+func (s *Scheduler) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			today, err := ParseDate(r.URL.Query().Get("date"))
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			agenda, err := s.Agenda(r.Context(), today)
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, agenda)
+
+		case http.MethodPost:
+			var req ReviewRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil || req.ID == "" || req.Quality == nil {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			today, err := ParseDate(req.Date)
+			if err != nil {
+				handler.WriteError(w, r, err)
+				return
+			}
+			card, err := s.Review(r.Context(), req.ID, *req.Quality, today)
+			if err != nil {
+				// 回忆质量无效为 400，卡片不存在为 404，运行队列已满为 429，其他为 500
+				handler.WriteError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, card)
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// PageHandler /review 今天要复习的练习和模块
+func (s *Scheduler) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		today, err := ParseDate(r.URL.Query().Get("date"))
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+		agenda, err := s.Agenda(r.Context(), today)
+		if err != nil {
+			handler.WritePageError(w, r, err)
+			return
+		}
+
+		// 回忆质量按钮 0~MaxQuality，文字在消息目录的 review.quality_N 中
+		qualities := make([]int, MaxQuality+1)
+		for q := range qualities {
+			qualities[q] = q
+		}
+		pages.Render(w, r, "review", struct {
+			*Agenda
+			Qualities []int
+		}{agenda, qualities})
+	}
+}
@@ -0,0 +1,328 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/fsutil"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/progress"
+)
+
+// StateFile 复习状态文件，相对仓库根目录
+const StateFile = ".study/review.json"
+
+// DateLayout 日期格式，复习按天安排
+const DateLayout = "2006-01-02"
+
+// 复习卡片类别
+const (
+	KindExercise = "exercise" // 完成的 exercises/dayNN
+	KindModule   = "module"   // 对应练习全部完成的 gobase 模块
+)
+
+// SM-2 参数
+const (
+	initialEase = 2.5
+	minEase     = 1.3
+	MaxQuality  = 5 // 回忆质量 0~5，小于 3 视为遗忘
+)
+
+// 复习错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrUnknownCard 卡片不存在
+	ErrUnknownCard = apperr.New(apperr.NotFound, "card_not_found", "review.unknown_card")
+	// ErrInvalidQuality 回忆质量不在 0~MaxQuality 之间
+	ErrInvalidQuality = apperr.New(apperr.Validation, "invalid_quality", "review.invalid_quality", MaxQuality)
+	// ErrInvalidDate 日期不是 2006-01-02 格式
+	ErrInvalidDate = apperr.New(apperr.Validation, "invalid_date", "review.invalid_date")
+)
+
+// Record 一次复习记录
+type Record struct {
+	Date    string `json:"date"`
+	Quality int    `json:"quality"`
+}
+
+// State 一张卡片的 SM-2 状态
+type State struct {
+	Ease        float64  `json:"ease"`        // 难度系数，初始 2.5，最低 1.3
+	Interval    int      `json:"interval"`    // 当前间隔天数
+	Repetitions int      `json:"repetitions"` // 连续记住的次数
+	Added       string   `json:"added"`       // 首次发现完成的日期
+	Due         string   `json:"due"`         // 下次复习日期
+	History     []Record `json:"history,omitempty"`
+}
+
+// Link 卡片上的链接
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Card 一张复习卡片：练习或模块及其复习状态
+type Card struct {
+	ID    string `json:"id"` // exercises/day03 或 gobase/slices-maps
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Links []Link `json:"links"`
+	State
+	Overdue int `json:"overdue"` // 逾期天数
+}
+
+// Agenda 某一天的复习安排
+type Agenda struct {
+	Date     string `json:"date"`
+	Due      []Card `json:"due"`      // 今天（及之前）到期的
+	Upcoming []Card `json:"upcoming"` // 之后 7 天内到期的
+	Total    int    `json:"total"`    // 全部卡片数
+}
+
+// Scheduler 按 SM-2 算法安排已完成练习和模块的复习，状态保存在本地 JSON 文件中
+type Scheduler struct {
+	cat     *catalog.Catalog
+	tracker *progress.Tracker
+
+	mu sync.Mutex // 保护状态文件的读改写
+}
+
+// New 创建复习调度器，完成情况来自学习进度跟踪器
+func New(cat *catalog.Catalog, tracker *progress.Tracker) *Scheduler {
+	return &Scheduler{cat: cat, tracker: tracker}
+}
+
+// path 状态文件在磁盘上的路径
+func (s *Scheduler) path() string {
+	return filepath.Join(s.cat.Root(), filepath.FromSlash(StateFile))
+}
+
+// Agenda 同步新完成的卡片，返回 today 的复习安排
+func (s *Scheduler) Agenda(ctx context.Context, today time.Time) (*Agenda, error) {
+	cards, err := s.sync(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+	date := today.Format(DateLayout)
+	horizon := today.AddDate(0, 0, 7).Format(DateLayout)
+	agenda := &Agenda{Date: date, Due: []Card{}, Upcoming: []Card{}, Total: len(cards)}
+	for _, c := range cards {
+		switch {
+		case c.Due <= date:
+			c.Overdue = daysBetween(c.Due, date)
+			agenda.Due = append(agenda.Due, c)
+		case c.Due <= horizon:
+			agenda.Upcoming = append(agenda.Upcoming, c)
+		}
+	}
+	// 逾期越久越靠前
+	sort.SliceStable(agenda.Due, func(i, j int) bool { return agenda.Due[i].Due < agenda.Due[j].Due })
+	sort.SliceStable(agenda.Upcoming, func(i, j int) bool { return agenda.Upcoming[i].Due < agenda.Upcoming[j].Due })
+	return agenda, nil
+}
+
+// Review 记录一次复习，按 SM-2 更新间隔和下次复习日期
+func (s *Scheduler) Review(ctx context.Context, id string, quality int, today time.Time) (*Card, error) {
+	if quality < 0 || quality > MaxQuality {
+		return nil, ErrInvalidQuality
+	}
+	cards, err := s.sync(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+	var card *Card
+	for i := range cards {
+		if cards[i].ID == id {
+			card = &cards[i]
+		}
+	}
+	if card == nil {
+		return nil, ErrUnknownCard.With(id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	st := states[id]
+	st.schedule(quality, today)
+	states[id] = st
+	if err := s.save(states); err != nil {
+		return nil, err
+	}
+	card.State = *st
+	return card, nil
+}
+
+// schedule SM-2：记住（质量 ≥ 3）时间隔依次为 1 天、6 天、上次间隔 × 难度系数，遗忘时从 1 天重新开始；
+// 难度系数按 EF' = EF + (0.1 - (5-q) × (0.08 + (5-q) × 0.02)) 调整
+func (st *State) schedule(quality int, today time.Time) {
+	if quality < 3 {
+		st.Repetitions = 0
+		st.Interval = 1
+	} else {
+		st.Repetitions++
+		switch st.Repetitions {
+		case 1:
+			st.Interval = 1
+		case 2:
+			st.Interval = 6
+		default:
+			st.Interval = int(math.Round(float64(st.Interval) * st.Ease))
+		}
+	}
+	q := float64(MaxQuality - quality)
+	st.Ease = math.Max(minEase, st.Ease+0.1-q*(0.08+q*0.02))
+	st.Due = today.AddDate(0, 0, st.Interval).Format(DateLayout)
+	st.History = append(st.History, Record{Date: today.Format(DateLayout), Quality: quality})
+}
+
+// sync 把新完成的练习和模块加入状态文件（第二天开始复习），返回全部卡片
+func (s *Scheduler) sync(ctx context.Context, today time.Time) ([]Card, error) {
+	summary, err := s.tracker.Summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cards := s.completed(i18n.FromContext(ctx), summary.Days)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for i, c := range cards {
+		st, ok := states[c.ID]
+		if !ok {
+			st = &State{
+				Ease:  initialEase,
+				Added: today.Format(DateLayout),
+				Due:   today.AddDate(0, 0, 1).Format(DateLayout),
+			}
+			states[c.ID] = st
+			changed = true
+		}
+		cards[i].State = *st
+	}
+	if changed {
+		if err := s.save(states); err != nil {
+			return nil, err
+		}
+	}
+	return cards, nil
+}
+
+// completed 列出已完成的练习，以及对应练习天全部完成的 gobase 模块，链接文字按 locale 翻译
+func (s *Scheduler) completed(locale i18n.Locale, days []progress.DayStatus) []Card {
+	done := make(map[int]bool)
+	var cards []Card
+	for _, d := range days {
+		if !d.Done() {
+			continue
+		}
+		done[d.Day] = true
+		cards = append(cards, Card{
+			ID:    "exercises/" + d.Group,
+			Kind:  KindExercise,
+			Title: fmt.Sprintf("Day %02d · %s", d.Day, d.Topic),
+			Links: []Link{
+				{Label: i18n.T(locale, "review.link_exercise"), URL: d.Route},
+				{Label: i18n.T(locale, "review.link_compare"), URL: "/compare/" + d.Group},
+				{Label: i18n.T(locale, "review.link_grade"), URL: "/grade/" + d.Group},
+			},
+		})
+	}
+
+	for _, m := range s.cat.Manifest().Modules {
+		if len(m.ExerciseDays) == 0 {
+			continue
+		}
+		complete := true
+		for _, day := range m.ExerciseDays {
+			complete = complete && done[day]
+		}
+		if !complete {
+			continue
+		}
+		links := []Link{}
+		if e, ok := s.cat.ModuleEntry(m.ID); ok {
+			links = append(links, Link{Label: i18n.T(locale, "review.link_source"), URL: e.Route})
+		}
+		for _, day := range m.ExerciseDays {
+			group := fmt.Sprintf("day%02d", day)
+			if files := s.cat.Day(group); len(files) > 0 {
+				links = append(links, Link{Label: "📝 " + group, URL: files[0].Route})
+			}
+		}
+		cards = append(cards, Card{
+			ID:    "gobase/" + m.ID,
+			Kind:  KindModule,
+			Title: m.Title + " (" + m.File + ")",
+			Links: links,
+		})
+	}
+	return cards
+}
+
+// load 读取状态文件，不存在时为空
+func (s *Scheduler) load() (map[string]*State, error) {
+	data, err := os.ReadFile(s.path())
+	if os.IsNotExist(err) {
+		return make(map[string]*State), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]*State)
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", StateFile, err)
+	}
+	return states, nil
+}
+
+// save 原子地写回状态文件
+func (s *Scheduler) save(states map[string]*State) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path()), 0o755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(s.path(), data, 0o644)
+}
+
+// ParseDate 解析 2006-01-02 形式的日期，为空时为今天
+func ParseDate(s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	t, err := time.ParseInLocation(DateLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate.With(s)
+	}
+	return t, nil
+}
+
+// daysBetween 两个日期相差的天数
+func daysBetween(from, to string) int {
+	a, err1 := time.Parse(DateLayout, from)
+	b, err2 := time.Parse(DateLayout, to)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	return int(b.Sub(a).Hours() / 24)
+}
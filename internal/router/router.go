@@ -0,0 +1,212 @@
+// Package router 基于 Go 1.22 http.ServeMux 的路由：支持 "GET /users/{id}" 形式的模式、
+// 带类型的路径参数、共享前缀和中间件的路由组、自动 405（带 Allow 头）和路由列表
+package router
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-web-api-study/internal/apperr"
+)
+
+// ErrInvalidParam 路径参数缺失或格式错误，handler.WriteError 返回 400
+var ErrInvalidParam = apperr.New(apperr.Validation, "invalid_param", "request.invalid_param")
+
+// Middleware 中间件，与 internal/middleware 中的函数签名相同
+type Middleware func(http.Handler) http.Handler
+
+// Route 一条已注册的路由
+type Route struct {
+	Method  string `json:"method"` // 为空表示接受任意方法
+	Pattern string `json:"pattern"`
+}
+
+// Router 路由器。路由应在开始处理请求之前注册完毕
+type Router struct {
+	// NotFound 没有匹配的路径时调用，默认为 http.NotFound
+	NotFound http.HandlerFunc
+	// MethodNotAllowed 路径匹配但方法不匹配时调用，调用前已设置 Allow 头；默认返回纯文本 405
+	MethodNotAllowed http.HandlerFunc
+
+	root    *Group
+	mux     *http.ServeMux
+	methods []string // 注册过的方法，用于计算 Allow 头
+	routes  []Route
+}
+
+// New 创建路由器
+func New() *Router {
+	rt := &Router{mux: http.NewServeMux()}
+	rt.root = &Group{router: rt}
+	return rt
+}
+
+// Group 创建路由组，见 Group.Group
+func (rt *Router) Group(prefix string, mw ...Middleware) *Group {
+	return rt.root.Group(prefix, mw...)
+}
+
+// Use 为之后注册的全部路由添加中间件，见 Group.Use
+func (rt *Router) Use(mw ...Middleware) {
+	rt.root.Use(mw...)
+}
+
+// Handle 注册路由，见 Group.Handle
+func (rt *Router) Handle(pattern string, h http.Handler) {
+	rt.root.Handle(pattern, h)
+}
+
+// HandleFunc 注册处理函数
+func (rt *Router) HandleFunc(pattern string, h http.HandlerFunc) {
+	rt.root.Handle(pattern, h)
+}
+
+// ServeHTTP 分发请求。没有匹配的路由时，路径能匹配其他方法则回复 OPTIONS 或 405，否则调用 NotFound
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := rt.mux.Handler(r); pattern != "" {
+		rt.mux.ServeHTTP(w, r)
+		return
+	}
+	allowed := rt.allowed(r)
+	if len(allowed) == 0 {
+		rt.notFound(w, r)
+		return
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if rt.MethodNotAllowed != nil {
+		rt.MethodNotAllowed(w, r)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+// allowed 依次用注册过的方法匹配请求路径，返回能匹配的方法；
+// 注册了 GET 时也允许 HEAD（ServeMux 的 GET 模式同时匹配 HEAD），有匹配时总是允许 OPTIONS
+func (rt *Router) allowed(r *http.Request) []string {
+	set := make(map[string]bool)
+	probe := *r
+	for _, m := range rt.methods {
+		probe.Method = m
+		if _, pattern := rt.mux.Handler(&probe); pattern != "" {
+			set[m] = true
+			if m == http.MethodGet {
+				set[http.MethodHead] = true
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	set[http.MethodOptions] = true
+	list := make([]string, 0, len(set))
+	for m := range set {
+		list = append(list, m)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Routes 返回已注册的路由，按路径模式和方法排序
+func (rt *Router) Routes() []Route {
+	routes := append([]Route(nil), rt.routes...)
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// handle 注册一条路由，method 为空表示接受任意方法；模式冲突或重复时 ServeMux 会 panic
+func (rt *Router) handle(method, path string, h http.Handler) {
+	if method == "" {
+		rt.mux.Handle(path, h)
+	} else {
+		rt.mux.Handle(method+" "+path, h)
+		if !slices.Contains(rt.methods, method) {
+			rt.methods = append(rt.methods, method)
+		}
+	}
+	rt.routes = append(rt.routes, Route{Method: method, Pattern: path})
+}
+
+func (rt *Router) notFound(w http.ResponseWriter, r *http.Request) {
+	if rt.NotFound != nil {
+		rt.NotFound(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// Group 共享路径前缀和中间件的一组路由
+type Group struct {
+	router     *Router
+	prefix     string
+	middleware []Middleware
+}
+
+// Group 创建子路由组，前缀拼接在当前组之后，继承当前组的中间件
+func (g *Group) Group(prefix string, mw ...Middleware) *Group {
+	return &Group{
+		router:     g.router,
+		prefix:     g.prefix + strings.TrimSuffix(prefix, "/"),
+		middleware: append(append([]Middleware(nil), g.middleware...), mw...),
+	}
+}
+
+// Path 返回组内路径的完整路径，如 Group("/api/v1").Path("/users/1") 为 /api/v1/users/1
+func (g *Group) Path(p string) string {
+	return g.prefix + p
+}
+
+// Use 为之后在本组注册的路由添加中间件，先添加的在外层
+func (g *Group) Use(mw ...Middleware) {
+	g.middleware = append(g.middleware, mw...)
+}
+
+// Handle 注册路由，pattern 为 "GET /users/{id}" 或不带方法的 "/docs/"，路径拼接在组前缀之后
+func (g *Group) Handle(pattern string, h http.Handler) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	path = strings.TrimLeft(path, " ")
+	if !strings.HasPrefix(path, "/") {
+		panic("router: 路径必须以 / 开头: " + pattern)
+	}
+	if path == "/" && g.prefix != "" {
+		path = "" // 组前缀本身，如 Group("/api").Handle("GET /", h) 注册 GET /api
+	}
+	for i := len(g.middleware) - 1; i >= 0; i-- {
+		h = g.middleware[i](h)
+	}
+	g.router.handle(method, g.prefix+path, h)
+}
+
+// HandleFunc 注册处理函数
+func (g *Group) HandleFunc(pattern string, h http.HandlerFunc) {
+	g.Handle(pattern, h)
+}
+
+// Param 返回路径参数的原始值
+func Param(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+// ParamInt 把路径参数解析为整数，缺失或格式错误时返回 ErrInvalidParam
+func ParamInt(r *http.Request, name string) (int, error) {
+	v := r.PathValue(name)
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, ErrInvalidParam.With(name, v)
+	}
+	return n, nil
+}
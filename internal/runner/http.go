@@ -0,0 +1,76 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/i18n"
+)
+
+// RunRequest 运行请求
+type RunRequest struct {
+	Path string `json:"path"` // 相对仓库根目录的文件路径，如 gobase/01_variables_and_types.go
+}
+
+// Handler 返回 POST 运行接口，输出以 Server-Sent Events 流式返回：
+// stdout/stderr/status 事件的 data 为 Event JSON，最后一个 exit 事件的 data 为 Result JSON。
+// resolve 把请求中的路径映射为磁盘上的文件，只允许运行目录中已知的文件。
+func (r *Runner) Handler(resolve func(path string) (string, bool)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			handler.LocalizedErrorResponse(w, req, http.StatusMethodNotAllowed, "request.post_only")
+			return
+		}
+
+		var body RunRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 4096)).Decode(&body); err != nil {
+			handler.LocalizedErrorResponse(w, req, http.StatusBadRequest, "request.malformed")
+			return
+		}
+		filePath, ok := resolve(body.Path)
+		if !ok {
+			handler.LocalizedErrorResponse(w, req, http.StatusNotFound, "file.not_found_path", body.Path)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			handler.LocalizedErrorResponse(w, req, http.StatusInternalServerError, "request.no_streaming")
+			return
+		}
+
+		started := false
+		start := func() {
+			if started {
+				return
+			}
+			started = true
+			w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+		}
+		send := func(event string, v interface{}) {
+			start()
+			data, _ := json.Marshal(v)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+			flusher.Flush()
+		}
+
+		result, err := r.Run(req.Context(), filePath, func(ev Event) {
+			send(ev.Stream, ev)
+		})
+		if err != nil {
+			if started {
+				send("error", map[string]string{"message": i18n.Message(i18n.Of(req), err)})
+				return
+			}
+			// 运行队列已满为 429，读取源文件等运行器内部错误为 500
+			handler.WriteError(w, req, err)
+			return
+		}
+		send("exit", result)
+	}
+}
@@ -0,0 +1,20 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// isolateNetwork 让命令在新的用户和网络命名空间中运行：命名空间中只有未启用的 lo，
+// 程序无法访问任何网络。用户命名空间中的 uid/gid 与当前用户相同，文件权限不变。
+// 必须在 setProcessGroup 之后调用；内核禁用了非特权用户命名空间时 Start 会失败
+func isolateNetwork(cmd *exec.Cmd) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET
+	cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package runner
+
+import "os/exec"
+
+// isolateNetwork 只有 Linux 支持网络命名空间，其他系统上返回 ErrNoIsolation，
+// 需要设置 Config.AllowNetwork 才能运行程序
+func isolateNetwork(cmd *exec.Cmd) error {
+	return ErrNoIsolation
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// setProcessGroup 非 Unix 系统上没有进程组，取消时只杀死直接启动的进程
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup 非 Unix 系统上由 exec.CommandContext 负责杀死进程
+func killProcessGroup(cmd *exec.Cmd) error { return nil }
@@ -0,0 +1,346 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/i18n"
+)
+
+// ErrBusy 同时运行的程序数已达上限
+var ErrBusy = apperr.New(apperr.RateLimited, "runner_busy", "runner.busy")
+
+// ErrNoIsolation 无法在隔离网络的环境中运行程序：不是 Linux，或内核禁用了非特权用户命名空间。
+// 属于服务器配置问题，原因写入日志；确实要在没有隔离的情况下运行时设置 Config.AllowNetwork
+var ErrNoIsolation = apperr.New(apperr.Internal, "runner_no_isolation", "runner.no_isolation")
+
+// 输出事件类型
+const (
+	StreamStdout = "stdout" // 程序标准输出
+	StreamStderr = "stderr" // 程序标准错误（包括编译错误）
+	StreamStatus = "status" // 运行阶段提示，如 "编译中"
+)
+
+// Config 运行器配置
+type Config struct {
+	GoBin         string        // go 命令，默认 "go"
+	GoCache       string        // 共享的构建缓存目录，默认使用 go env GOCACHE
+	Timeout       time.Duration // 编译加运行的总时长上限
+	MaxOutput     int64         // stdout+stderr 的总字节数上限
+	MaxConcurrent int           // 同时运行的程序数上限
+	QueueWait     time.Duration // 运行队列已满时的最长等待时间
+	AllowNetwork  bool          // 为 true 时程序直接以服务器权限运行，不隔离网络；默认在新的网络命名空间中运行
+}
+
+// DefaultConfig 返回适合课堂使用的默认配置；环境变量 RUN_ALLOW_NETWORK=1 时不隔离网络，
+// 用于不支持网络命名空间的系统
+func DefaultConfig() Config {
+	return Config{
+		GoBin:         "go",
+		Timeout:       15 * time.Second,
+		MaxOutput:     256 << 10,
+		MaxConcurrent: 2,
+		QueueWait:     5 * time.Second,
+		AllowNetwork:  os.Getenv("RUN_ALLOW_NETWORK") == "1",
+	}
+}
+
+// Event 运行过程中的一条输出
+type Event struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// Result 一次运行的结果
+type Result struct {
+	Stage      string `json:"stage"`     // 结束时所处阶段：build 或 run（Check 只有 build）
+	ExitCode   int    `json:"exit_code"` // 进程退出码，未能启动时为 -1
+	DurationMS int64  `json:"duration_ms"`
+	TimedOut   bool   `json:"timed_out"`
+	Truncated  bool   `json:"truncated"` // 输出超过上限被截断并终止
+}
+
+// OK 程序编译并运行成功
+func (r Result) OK() bool {
+	return r.Stage == "run" && r.ExitCode == 0 && !r.TimedOut && !r.Truncated
+}
+
+// Runner 在临时目录中编译并运行单个 Go 文件
+type Runner struct {
+	cfg Config
+	sem chan struct{}
+}
+
+// New 创建运行器
+func New(cfg Config) *Runner {
+	def := DefaultConfig()
+	if cfg.GoBin == "" {
+		cfg.GoBin = def.GoBin
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = def.Timeout
+	}
+	if cfg.MaxOutput <= 0 {
+		cfg.MaxOutput = def.MaxOutput
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = def.MaxConcurrent
+	}
+	if cfg.GoCache == "" {
+		if out, err := exec.Command(cfg.GoBin, "env", "GOCACHE").Output(); err == nil {
+			cfg.GoCache = strings.TrimSpace(string(out))
+		}
+	}
+	return &Runner{cfg: cfg, sem: make(chan struct{}, cfg.MaxConcurrent)}
+}
+
+// acquire 占用一个运行名额，队列已满时最多等待 QueueWait
+func (r *Runner) acquire(ctx context.Context) error {
+	select {
+	case r.sem <- struct{}{}:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(r.cfg.QueueWait)
+	defer timer.Stop()
+	select {
+	case r.sem <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run 把 filePath 复制到临时目录，使用本地 Go 工具链编译后运行，输出通过 emit 逐行回调。
+// 程序在新的网络命名空间中运行，不能访问网络（见 Config.AllowNetwork），但仍以服务器进程的
+// 用户权限读写文件，只应运行可信的代码（仓库中的练习和模块）。
+// emit 会被串行调用；返回的 error 仅表示运行器本身的问题（如队列已满、无法隔离网络），
+// 编译失败、超时等情况记录在 Result 中。
+func (r *Runner) Run(ctx context.Context, filePath string, emit func(Event)) (Result, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return Result{}, fmt.Errorf("读取源文件失败: %w", err)
+	}
+	return r.do(ctx, filePath, src, true, emit)
+}
+
+// Check 只编译不运行，用于检查文件能否通过编译
+func (r *Runner) Check(ctx context.Context, filePath string, emit func(Event)) (Result, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return Result{}, fmt.Errorf("读取源文件失败: %w", err)
+	}
+	return r.do(ctx, filePath, src, false, emit)
+}
+
+// RunSource 编译并运行内存中的源码，name 只用于状态提示
+func (r *Runner) RunSource(ctx context.Context, name string, src []byte, emit func(Event)) (Result, error) {
+	return r.do(ctx, name, src, true, emit)
+}
+
+// do 在临时目录中编译 src，execute 为 true 时继续运行编译结果
+func (r *Runner) do(ctx context.Context, filePath string, src []byte, execute bool, emit func(Event)) (Result, error) {
+	if err := r.acquire(ctx); err != nil {
+		return Result{}, err
+	}
+	defer func() { <-r.sem }()
+
+	dir, err := os.MkdirTemp("", "study-run-")
+	if err != nil {
+		return Result{}, fmt.Errorf("创建临时目录失败: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0o644); err != nil {
+		return Result{}, fmt.Errorf("写入临时文件失败: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	out := &output{emit: emit, limit: r.cfg.MaxOutput, cancel: cancel, locale: i18n.FromContext(ctx)}
+	result := Result{Stage: "build", ExitCode: -1}
+	finish := func() (Result, error) {
+		result.DurationMS = time.Since(start).Milliseconds()
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		result.Truncated = out.truncated
+		return result, nil
+	}
+
+	binary := filepath.Join(dir, "main")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	out.send(Event{Stream: StreamStatus, Data: i18n.T(out.locale, "runner.building", filepath.ToSlash(filePath))})
+	build := exec.CommandContext(ctx, r.cfg.GoBin, "build", "-o", binary, "main.go")
+	build.Dir = dir
+	build.Env = r.buildEnv(dir)
+	// 超时时杀死整个进程组，go build 启动的编译器进程也不会留下
+	setProcessGroup(build)
+	result.ExitCode, _ = r.exec(build, out)
+	if result.ExitCode != 0 || !execute {
+		return finish()
+	}
+
+	result.Stage = "run"
+	out.send(Event{Stream: StreamStatus, Data: i18n.T(out.locale, "runner.running")})
+	prog := exec.CommandContext(ctx, binary)
+	prog.Dir = dir
+	prog.Env = r.runEnv(dir)
+	// 超时时杀死整个进程组；除非明确允许，程序在没有网络的命名空间中运行
+	setProcessGroup(prog)
+	if !r.cfg.AllowNetwork {
+		if err := isolateNetwork(prog); err != nil {
+			return result, err
+		}
+	}
+	code, err := r.exec(prog, out)
+	if err != nil && !r.cfg.AllowNetwork {
+		// 创建命名空间失败时 Start 出错，不退回到不隔离的运行方式
+		return result, ErrNoIsolation.Wrap(err)
+	}
+	result.ExitCode = code
+	return finish()
+}
+
+// exec 运行已经设置好进程组的命令并把 stdout/stderr 转发给 out，返回退出码；命令无法启动时返回 -1 和启动错误
+func (r *Runner) exec(cmd *exec.Cmd, out *output) (int, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		out.send(Event{Stream: StreamStderr, Data: err.Error()})
+		return -1, nil
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		out.send(Event{Stream: StreamStderr, Data: err.Error()})
+		return -1, nil
+	}
+	// 进程被杀死后，最多再等 1 秒让管道关闭
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Start(); err != nil {
+		out.send(Event{Stream: StreamStderr, Data: err.Error()})
+		return -1, err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); out.copy(StreamStdout, stdout) }()
+	go func() { defer wg.Done(); out.copy(StreamStderr, stderr) }()
+	wg.Wait()
+
+	err = cmd.Wait()
+	// 进程正常退出后也清理它留在后台的子进程
+	killProcessGroup(cmd)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// buildEnv 编译阶段的环境变量：不继承当前进程环境，禁用模块代理和工具链下载
+func (r *Runner) buildEnv(dir string) []string {
+	env := r.runEnv(dir)
+	env = append(env,
+		"GOPATH="+filepath.Join(dir, "gopath"),
+		"GOENV=off",
+		"GOPROXY=off",
+		"GOSUMDB=off",
+		"GOTOOLCHAIN=local",
+		"GOFLAGS=-mod=mod",
+		"CGO_ENABLED=0",
+	)
+	if r.cfg.GoCache != "" {
+		env = append(env, "GOCACHE="+r.cfg.GoCache)
+	} else {
+		env = append(env, "GOCACHE="+filepath.Join(dir, "gocache"))
+	}
+	return env
+}
+
+// runEnv 运行阶段的最小环境变量，不包含代理配置；网络由 isolateNetwork 隔离，
+// 程序仍然可以访问服务器上当前用户可读的文件
+func (r *Runner) runEnv(dir string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+	}
+	if runtime.GOOS == "windows" {
+		env = append(env,
+			"SystemRoot="+os.Getenv("SystemRoot"),
+			"USERPROFILE="+dir,
+			"TEMP="+dir,
+			"TMP="+dir,
+		)
+	}
+	return env
+}
+
+// output 串行转发输出事件，并统计总字节数
+type output struct {
+	mu        sync.Mutex
+	emit      func(Event)
+	limit     int64
+	written   int64
+	truncated bool
+	cancel    context.CancelFunc
+	locale    i18n.Locale // 状态提示的语言，取自请求的 context
+}
+
+// send 转发一条事件，超过输出上限后丢弃并终止进程
+func (o *output) send(ev Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.truncated {
+		return
+	}
+	if ev.Stream != StreamStatus {
+		o.written += int64(len(ev.Data))
+		if o.written > o.limit {
+			o.truncated = true
+			o.emit(Event{Stream: StreamStatus, Data: i18n.T(o.locale, "runner.output_limit", o.limit)})
+			o.cancel()
+			return
+		}
+	}
+	o.emit(ev)
+}
+
+// copy 按行读取管道并转发，超长的行按缓冲区大小分段
+func (o *output) copy(stream string, r io.Reader) {
+	br := bufio.NewReader(r)
+	for {
+		chunk, err := br.ReadSlice('\n')
+		if len(chunk) > 0 {
+			o.send(Event{Stream: stream, Data: string(chunk)})
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/handler"
+)
+
+// Handler POST /api/v1/exercises {"day": 5, "name": "functions_practice"}：
+// 创建练习返回 201，练习已存在返回 409，计划中没有这一天返回 404，文件名无效返回 400
+func Handler(cat *catalog.Catalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.post_only")
+			return
+		}
+		var opts Options
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&opts); err != nil || opts.Day < 0 {
+			handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+			return
+		}
+
+		result, err := Create(cat, opts)
+		if err != nil {
+			// 练习已存在为 409，计划中没有这一天为 404，文件名无效为 400，其他为 500
+			handler.WriteError(w, r, err)
+			return
+		}
+		handler.CreatedResponse(w, result)
+	}
+}
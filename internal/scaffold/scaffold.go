@@ -0,0 +1,257 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/fsutil"
+)
+
+// 新建练习错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	// ErrDayExists 练习目录已存在，不会覆盖
+	ErrDayExists = apperr.New(apperr.Conflict, "day_exists", "scaffold.day_exists")
+	// ErrNotInPlan 练习计划中没有这一天
+	ErrNotInPlan = apperr.New(apperr.NotFound, "day_not_in_plan", "scaffold.not_in_plan")
+	// ErrInvalidName 练习文件名不符合 ValidName
+	ErrInvalidName = apperr.New(apperr.Validation, "invalid_name", "scaffold.invalid_name")
+)
+
+// Options 新建练习的参数
+type Options struct {
+	Day  int       `json:"day"`  // 为 0 时使用已有练习之后的下一天
+	Name string    `json:"name"` // 文件名（不含 .go），为空时由参考模块推导
+	Date time.Time `json:"-"`    // 文件头中的日期，为零值时使用当前时间
+}
+
+// Result 新建的练习
+type Result struct {
+	Day    int    `json:"day"`
+	Group  string `json:"group"`
+	Topic  string `json:"topic"`
+	Module string `json:"module,omitempty"` // 参考的 gobase 文件名
+	Path   string `json:"path"`
+	Route  string `json:"route"`
+}
+
+// NextDay 返回已有练习之后的下一天
+func NextDay(cat *catalog.Catalog) int {
+	last := 0
+	for _, e := range cat.Entries(catalog.KindExercise) {
+		last = max(last, e.Order)
+	}
+	return last + 1
+}
+
+// Create 按练习计划创建 exercises/dayNN/，文件头填好日期和当天主题，
+// 有参考模块时根据模块 main 中调用的演示函数生成骨架；目录已存在时返回 ErrDayExists
+func Create(cat *catalog.Catalog, opts Options) (*Result, error) {
+	day := opts.Day
+	if day == 0 {
+		day = NextDay(cat)
+	}
+	plan, ok := catalog.PlanDayByNumber(cat.Plan(), day)
+	if !ok {
+		return nil, ErrNotInPlan.With(day)
+	}
+
+	group := plan.Group()
+	dir := filepath.Join(cat.Root(), "exercises", group)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, ErrDayExists.With("exercises/" + group)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = defaultName(cat, plan)
+	}
+	if !ValidName(name) {
+		return nil, ErrInvalidName.With(name)
+	}
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	src, err := render(cat, plan, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 目录已存在时 Mkdir 失败，避免并发创建同一天时互相覆盖
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		if os.IsExist(err) {
+			return nil, ErrDayExists.With("exercises/" + group)
+		}
+		return nil, err
+	}
+	rel := "exercises/" + group + "/" + name + ".go"
+	if err := fsutil.WriteFileAtomic(filepath.Join(cat.Root(), filepath.FromSlash(rel)), src, 0o644); err != nil {
+		// 删除刚创建的空目录，否则之后每次创建这一天都会返回 ErrDayExists
+		os.Remove(dir)
+		return nil, err
+	}
+	if err := cat.Reload(); err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Day:    day,
+		Group:  group,
+		Topic:  plan.Topic,
+		Module: plan.Module,
+		Path:   rel,
+		Route:  "/exercises/" + group + "/" + name,
+	}, nil
+}
+
+// defaultName 由参考模块推导文件名，例如 slices-maps -> slices_maps_practice
+func defaultName(cat *catalog.Catalog, plan catalog.PlanDay) string {
+	if plan.Module != "" {
+		if m, ok := cat.Manifest().ModuleByFile(plan.Module); ok {
+			return strings.ReplaceAll(m.ID, "-", "_") + "_practice"
+		}
+	}
+	return "practice"
+}
+
+// ValidName 练习文件名（不含 .go）只允许小写字母、数字和下划线
+func ValidName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// demoFunc 参考模块中可作为练习骨架的演示函数
+type demoFunc struct {
+	name string
+	doc  string
+	line int
+}
+
+// render 生成练习文件内容
+func render(cat *catalog.Catalog, plan catalog.PlanDay, date time.Time) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Day %02d - %s\n", plan.Day, plan.Topic)
+	fmt.Fprintf(&b, "// 日期: %d年%d月%d日\n", date.Year(), date.Month(), date.Day())
+	fmt.Fprintf(&b, "// 学习内容: %s\n", plan.Topic)
+
+	var funcs []demoFunc
+	if plan.Module != "" {
+		fmt.Fprintf(&b, "// 参考模块: gobase/%s\n", plan.Module)
+		all, err := demoFuncs(filepath.Join(cat.Root(), "gobase", plan.Module))
+		if err != nil {
+			return nil, err
+		}
+		funcs = share(all, cat.Manifest(), plan)
+	}
+	b.WriteString("\npackage main\n\nimport \"fmt\"\n\n")
+
+	for _, f := range funcs {
+		if f.doc != "" {
+			fmt.Fprintf(&b, "// %s\n", f.doc)
+		}
+		fmt.Fprintf(&b, "// TODO: 参考 gobase/%s 第 %d 行，自己动手实现一遍\n", plan.Module, f.line)
+		fmt.Fprintf(&b, "func %s() {\n\tfmt.Println(\"\\n=== %s ===\")\n}\n\n", f.name, f.name)
+	}
+
+	b.WriteString("func main() {\n")
+	fmt.Fprintf(&b, "\tfmt.Println(%q)\n", fmt.Sprintf("=== Day %02d - %s ===", plan.Day, plan.Topic))
+	if len(funcs) == 0 {
+		b.WriteString("\n\t// TODO: 在这里完成今天的练习\n")
+	}
+	for _, f := range funcs {
+		fmt.Fprintf(&b, "\t%s()\n", f.name)
+	}
+	b.WriteString("}\n")
+
+	return format.Source([]byte(b.String()))
+}
+
+// demoFuncs 找出模块 main 函数中直接调用、无参数无返回值的顶层函数，按调用顺序返回
+func demoFuncs(filename string) ([]demoFunc, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析参考模块失败: %w", err)
+	}
+
+	decls := make(map[string]*ast.FuncDecl)
+	var mainDecl *ast.FuncDecl
+	for _, d := range f.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		if fn.Name.Name == "main" {
+			mainDecl = fn
+			continue
+		}
+		if fn.Type.TypeParams == nil && fn.Type.Params.NumFields() == 0 && fn.Type.Results.NumFields() == 0 {
+			decls[fn.Name.Name] = fn
+		}
+	}
+	if mainDecl == nil || mainDecl.Body == nil {
+		return nil, nil
+	}
+
+	var funcs []demoFunc
+	seen := make(map[string]bool)
+	for _, stmt := range mainDecl.Body.List {
+		expr, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		call, ok := expr.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		if !ok || seen[ident.Name] || decls[ident.Name] == nil {
+			continue
+		}
+		seen[ident.Name] = true
+		fn := decls[ident.Name]
+		doc := ""
+		if text := strings.TrimSpace(fn.Doc.Text()); text != "" {
+			doc = strings.SplitN(text, "\n", 2)[0]
+		}
+		funcs = append(funcs, demoFunc{name: ident.Name, doc: doc, line: fset.Position(fn.Pos()).Line})
+	}
+	return funcs, nil
+}
+
+// share 模块对应多天练习时，把演示函数按顺序平均分给这几天
+func share(funcs []demoFunc, manifest *catalog.Manifest, plan catalog.PlanDay) []demoFunc {
+	m, ok := manifest.ModuleByFile(plan.Module)
+	if !ok || len(m.ExerciseDays) < 2 || len(funcs) < len(m.ExerciseDays) {
+		return funcs
+	}
+	n := len(m.ExerciseDays)
+	for i, d := range m.ExerciseDays {
+		if d == plan.Day {
+			return funcs[i*len(funcs)/n : (i+1)*len(funcs)/n]
+		}
+	}
+	return funcs
+}
@@ -0,0 +1,50 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/web"
+)
+
+// defaultLimit 默认返回的文件数
+const defaultLimit = 20
+
+// APIHandler /api/v1/search?q=&limit=
+func (idx *Index) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+			return
+		}
+		q := strings.TrimSpace(r.URL.Query().Get("q"))
+		if q == "" {
+			handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.missing_query", "q")
+			return
+		}
+		limit := defaultLimit
+		if s := r.URL.Query().Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.limit_positive")
+				return
+			}
+			limit = n
+		}
+		handler.SuccessResponse(w, idx.Search(q, limit))
+	}
+}
+
+// PageHandler /search?q= 搜索页面
+func (idx *Index) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := strings.TrimSpace(r.URL.Query().Get("q"))
+		data := map[string]interface{}{"Query": q}
+		if q != "" {
+			data["Results"] = idx.Search(q, defaultLimit)
+		}
+		pages.Render(w, r, "search", data)
+	}
+}
@@ -0,0 +1,434 @@
+package search
+
+import (
+	"html"
+	"html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/symbols"
+)
+
+const (
+	// maxSnippets 每个文件最多返回的匹配行数
+	maxSnippets = 3
+	// snippetRunes 摘要的最大长度（字符数），超长的行围绕第一个命中截取
+	snippetRunes = 160
+	// maxSymbols 最多返回的符号数
+	maxSymbols = 20
+
+	// BM25 参数
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// document 单个文件的索引数据
+type document struct {
+	entry   catalog.Entry
+	modTime time.Time
+	size    int64
+	lines   []string
+	terms   map[string]int // 检索词 -> 出现次数
+	length  int            // 检索词总数
+}
+
+// Snippet 文件中的一个匹配行
+type Snippet struct {
+	Line int           `json:"line"`
+	URL  string        `json:"url"`
+	HTML template.HTML `json:"html"` // 已转义，命中部分包在 <mark> 中
+}
+
+// Result 一个匹配的文件
+type Result struct {
+	Kind     catalog.Kind `json:"kind"`
+	Path     string       `json:"path"`
+	Route    string       `json:"route"`
+	Title    string       `json:"title"`
+	Score    float64      `json:"score"`
+	Snippets []Snippet    `json:"snippets"`
+}
+
+// Results 一次检索的结果
+type Results struct {
+	Query   string           `json:"query"`
+	Total   int              `json:"total"` // 匹配的文件总数
+	Results []Result         `json:"results"`
+	Symbols []symbols.Symbol `json:"symbols"` // 名称匹配的函数、方法和类型
+}
+
+// Index 学习资料的全文索引，覆盖 gobase、exercises 中的 Go 源码和 Markdown 文档，
+// 每次检索前按修改时间增量更新
+type Index struct {
+	cat  *catalog.Catalog
+	syms *symbols.Index
+
+	mu       sync.RWMutex
+	docs     map[string]*document      // 按文件路径
+	postings map[string]map[string]int // 检索词 -> 文件路径 -> 出现次数
+	totalLen int
+}
+
+// NewIndex 创建全文索引并立即构建一次，syms 用于符号检索
+func NewIndex(cat *catalog.Catalog, syms *symbols.Index) *Index {
+	idx := &Index{
+		cat:      cat,
+		syms:     syms,
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]int),
+	}
+	idx.Refresh()
+	return idx
+}
+
+// Refresh 重新索引有改动的文件，移除已不在目录中的文件
+func (idx *Index) Refresh() {
+	entries := idx.cat.Entries("")
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	alive := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		alive[e.Path] = true
+		filename := filepath.Join(idx.cat.Root(), e.Path)
+		info, err := os.Stat(filename)
+		if err != nil {
+			continue
+		}
+		if doc, ok := idx.docs[e.Path]; ok && doc.modTime.Equal(info.ModTime()) && doc.size == info.Size() {
+			doc.entry = e
+			continue
+		}
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			continue
+		}
+		idx.remove(e.Path)
+		idx.add(newDocument(e, info, content))
+	}
+	for path := range idx.docs {
+		if !alive[path] {
+			idx.remove(path)
+		}
+	}
+}
+
+// newDocument 切分文件内容并统计检索词
+func newDocument(e catalog.Entry, info os.FileInfo, content []byte) *document {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	doc := &document{
+		entry:   e,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		lines:   strings.Split(text, "\n"),
+		terms:   make(map[string]int),
+	}
+	for _, t := range indexTokens(text) {
+		doc.terms[t]++
+		doc.length++
+	}
+	return doc
+}
+
+// add 把文件加入倒排索引，调用方需持有写锁
+func (idx *Index) add(doc *document) {
+	path := doc.entry.Path
+	idx.docs[path] = doc
+	idx.totalLen += doc.length
+	for t, n := range doc.terms {
+		if idx.postings[t] == nil {
+			idx.postings[t] = make(map[string]int)
+		}
+		idx.postings[t][path] = n
+	}
+}
+
+// remove 从倒排索引中删除文件，调用方需持有写锁
+func (idx *Index) remove(path string) {
+	doc, ok := idx.docs[path]
+	if !ok {
+		return
+	}
+	for t := range doc.terms {
+		delete(idx.postings[t], path)
+		if len(idx.postings[t]) == 0 {
+			delete(idx.postings, t)
+		}
+	}
+	idx.totalLen -= doc.length
+	delete(idx.docs, path)
+}
+
+// Search 检索包含全部检索词的文件，按 BM25 得分排序，最多返回 limit 个
+func (idx *Index) Search(query string, limit int) Results {
+	idx.Refresh()
+	if idx.syms != nil {
+		idx.syms.Refresh()
+	}
+	return idx.search(query, limit)
+}
+
+// search 在当前索引上检索，不刷新索引
+func (idx *Index) search(query string, limit int) Results {
+	terms := unique(Tokenize(query))
+	res := Results{Query: query, Results: []Result{}, Symbols: []symbols.Symbol{}}
+	if len(terms) == 0 {
+		return res
+	}
+	res.Symbols = idx.searchSymbols(query)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// 从文档频率最低的检索词开始求交集
+	sort.Slice(terms, func(i, j int) bool { return len(idx.postings[terms[i]]) < len(idx.postings[terms[j]]) })
+	var matched []string
+	for path := range idx.postings[terms[0]] {
+		all := true
+		for _, t := range terms[1:] {
+			if _, ok := idx.postings[t][path]; !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, path)
+		}
+	}
+
+	n := float64(len(idx.docs))
+	avgLen := float64(idx.totalLen) / math.Max(n, 1)
+	for _, path := range matched {
+		doc := idx.docs[path]
+		score := 0.0
+		for _, t := range terms {
+			df := float64(len(idx.postings[t]))
+			tf := float64(doc.terms[t])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(doc.length)/avgLen))
+		}
+		// 标题或文件名命中时加权
+		if containsAll(indexTokens(doc.entry.Title+" "+doc.entry.Path), terms) {
+			score *= 1.5
+		}
+		res.Results = append(res.Results, Result{
+			Kind:     doc.entry.Kind,
+			Path:     path,
+			Route:    doc.entry.Route,
+			Title:    doc.entry.Title,
+			Score:    math.Round(score*1000) / 1000,
+			Snippets: snippets(doc, terms),
+		})
+	}
+	sort.Slice(res.Results, func(i, j int) bool {
+		if res.Results[i].Score != res.Results[j].Score {
+			return res.Results[i].Score > res.Results[j].Score
+		}
+		return res.Results[i].Path < res.Results[j].Path
+	})
+
+	res.Total = len(res.Results)
+	if limit > 0 && len(res.Results) > limit {
+		res.Results = res.Results[:limit]
+	}
+	return res
+}
+
+// searchSymbols 返回名称（Recv.Name）包含查询中全部英文单词的符号，名称完全一致的排在前面
+func (idx *Index) searchSymbols(query string) []symbols.Symbol {
+	words := strings.Fields(strings.ToLower(query))
+	if idx.syms == nil || len(words) == 0 {
+		return []symbols.Symbol{}
+	}
+	for _, w := range words {
+		for _, r := range w {
+			if r != '.' && (!isWordRune(r) || isCJK(r)) {
+				return []symbols.Symbol{}
+			}
+		}
+	}
+
+	found := []symbols.Symbol{}
+	for _, s := range idx.syms.All() {
+		name := strings.ToLower(s.QualifiedName())
+		all := true
+		for _, w := range words {
+			if !strings.Contains(name, w) {
+				all = false
+				break
+			}
+		}
+		if all {
+			found = append(found, s)
+		}
+	}
+	exact := func(s symbols.Symbol) bool {
+		return len(words) == 1 && (strings.EqualFold(s.Name, words[0]) || strings.EqualFold(s.QualifiedName(), words[0]))
+	}
+	sort.SliceStable(found, func(i, j int) bool { return exact(found[i]) && !exact(found[j]) })
+	if len(found) > maxSymbols {
+		found = found[:maxSymbols]
+	}
+	return found
+}
+
+// snippets 选出命中检索词最多的几行，优先让每个检索词都至少出现一次，按行号排列
+func snippets(doc *document, terms []string) []Snippet {
+	type hit struct {
+		line  int
+		terms []string
+	}
+	var hits []hit
+	for i, line := range doc.lines {
+		lineTerms := unique(indexTokens(line))
+		var matched []string
+		for _, t := range terms {
+			if contains(lineTerms, t) {
+				matched = append(matched, t)
+			}
+		}
+		if len(matched) > 0 {
+			hits = append(hits, hit{line: i, terms: matched})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return len(hits[i].terms) > len(hits[j].terms) })
+
+	// 先挑能覆盖新检索词的行，再按命中数补足
+	var picked []hit
+	covered := make(map[string]bool)
+	used := make(map[int]bool)
+	for _, h := range hits {
+		if len(picked) == maxSnippets {
+			break
+		}
+		for _, t := range h.terms {
+			if !covered[t] {
+				picked = append(picked, h)
+				used[h.line] = true
+				for _, t := range h.terms {
+					covered[t] = true
+				}
+				break
+			}
+		}
+	}
+	for _, h := range hits {
+		if len(picked) == maxSnippets {
+			break
+		}
+		if !used[h.line] {
+			picked = append(picked, h)
+		}
+	}
+	hits = picked
+	sort.Slice(hits, func(i, j int) bool { return hits[i].line < hits[j].line })
+
+	result := make([]Snippet, 0, len(hits))
+	for _, h := range hits {
+		n := h.line + 1
+		result = append(result, Snippet{
+			Line: n,
+			URL:  doc.entry.Route + "#L" + strconv.Itoa(n),
+			HTML: template.HTML(Highlight(doc.lines[h.line], terms)),
+		})
+	}
+	return result
+}
+
+// Highlight 转义一行文本，把检索词的出现位置包在 <mark> 中；超长的行围绕第一个命中截取
+func Highlight(line string, terms []string) string {
+	runes := []rune(strings.TrimSpace(line))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, t := range terms {
+		tr := []rune(t)
+		for i := 0; i+len(tr) <= len(lower); i++ {
+			if string(lower[i:i+len(tr)]) != t {
+				continue
+			}
+			for j := i; j < i+len(tr); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(runes)
+	if len(runes) > snippetRunes {
+		start = max(0, first-snippetRunes/4)
+		end = min(len(runes), start+snippetRunes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	open := false
+	for i := start; i < end; i++ {
+		if marked[i] != open {
+			if marked[i] {
+				b.WriteString("<mark>")
+			} else {
+				b.WriteString("</mark>")
+			}
+			open = marked[i]
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+	}
+	if open {
+		b.WriteString("</mark>")
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// unique 去掉重复的检索词，保持原有顺序
+func unique(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	result := terms[:0:0]
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// contains 判断 terms 中是否包含 t
+func contains(terms []string, t string) bool {
+	for _, x := range terms {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll 判断 have 是否包含 want 中的全部检索词
+func containsAll(have, want []string) bool {
+	for _, t := range want {
+		if !contains(have, t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-web-api-study/internal/catalog"
+)
+
+// fakeInfo 只提供 newDocument 用到的文件信息
+type fakeInfo struct{ os.FileInfo }
+
+func (fakeInfo) ModTime() time.Time { return time.Time{} }
+func (fakeInfo) Size() int64        { return 0 }
+
+// newTestIndex 用内存中的文档构建索引，键为路径，值为内容
+func newTestIndex(docs map[string]string) *Index {
+	idx := &Index{docs: make(map[string]*document), postings: make(map[string]map[string]int)}
+	for path, content := range docs {
+		e := catalog.Entry{Path: path, Route: "/" + path, Title: path}
+		idx.add(newDocument(e, fakeInfo{}, []byte(content)))
+	}
+	return idx
+}
+
+// paths 返回检索结果中的文件路径
+func paths(res Results) []string {
+	var result []string
+	for _, r := range res.Results {
+		result = append(result, r.Path)
+	}
+	return result
+}
+
+func TestSearchRanking(t *testing.T) {
+	idx := newTestIndex(map[string]string{
+		"a.md": "channel channel channel goroutine",
+		"b.md": "channel goroutine select",
+		"c.md": "goroutine mutex 互斥锁保护共享变量",
+		"d.md": "channel " + strings.Repeat("filler ", 50),
+		"e.md": "锁",
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"higher term frequency ranks first", "channel", []string{"a.md", "b.md", "d.md"}},
+		{"all terms are required", "channel select", []string{"b.md"}},
+		{"rarer term weighs more", "goroutine mutex", []string{"c.md"}},
+		{"shorter document ranks first", "GOROUTINE", []string{"b.md", "a.md", "c.md"}},
+		{"single han character", "锁", []string{"e.md", "c.md"}},
+		{"han bigram", "互斥", []string{"c.md"}},
+		{"han phrase", "共享变量", []string{"c.md"}},
+		{"no match", "interface", nil},
+		{"no terms", "  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := idx.search(tt.query, 0)
+			if got := paths(res); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			if res.Total != len(tt.want) {
+				t.Errorf("Total = %d, want %d", res.Total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSearchTitleBoost(t *testing.T) {
+	idx := newTestIndex(map[string]string{"a.md": "select", "select.md": "select"})
+	if got, want := paths(idx.search("select", 0)), []string{"select.md", "a.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("search() = %v, want %v", got, want)
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	idx := newTestIndex(map[string]string{"a.md": "go", "b.md": "go go", "c.md": "go go go"})
+	res := idx.search("go", 2)
+	if res.Total != 3 || len(res.Results) != 2 {
+		t.Errorf("Total = %d, len(Results) = %d, want 3, 2", res.Total, len(res.Results))
+	}
+}
+
+func TestSearchSnippetsSingleHan(t *testing.T) {
+	idx := newTestIndex(map[string]string{"a.md": "标题\n使用互斥锁 <Mutex>\n结尾"})
+	res := idx.search("锁", 0)
+	if len(res.Results) != 1 {
+		t.Fatalf("search() = %v, want one result", paths(res))
+	}
+	want := []Snippet{{Line: 2, URL: "/a.md#L2", HTML: "使用互斥<mark>锁</mark> &lt;Mutex&gt;"}}
+	if got := res.Results[0].Snippets; !reflect.DeepEqual(got, want) {
+		t.Errorf("Snippets = %+v, want %+v", got, want)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		line  string
+		terms []string
+		want  string
+	}{
+		{"  no match  ", []string{"x"}, "no match"},
+		{"Go <b>&</b> go", []string{"go"}, "<mark>Go</mark> &lt;b&gt;&amp;&lt;/b&gt; <mark>go</mark>"},
+		{"学习要点", []string{"学习", "习要"}, "<mark>学习要</mark>点"},
+		{strings.Repeat("a", 200) + "go" + strings.Repeat("b", 200), []string{"go"},
+			"…" + strings.Repeat("a", 40) + "<mark>go</mark>" + strings.Repeat("b", 118) + "…"},
+	}
+	for _, tt := range tests {
+		if got := Highlight(tt.line, tt.terms); got != tt.want {
+			t.Errorf("Highlight(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
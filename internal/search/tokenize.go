@@ -0,0 +1,115 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 把文本切分为检索词：
+// 英文和数字按单词切分并转为小写，驼峰和下划线命名额外拆出各个部分（WithTimeout -> withtimeout, with, timeout）；
+// 中日韩文字按相邻两字切分（学习要点 -> 学习, 习要, 要点），单独一个字时保留单字。
+func Tokenize(text string) []string {
+	return tokenize(text, false)
+}
+
+// indexTokens 索引文件内容时使用的检索词：在 Tokenize 的基础上加入每个中日韩单字，
+// 使只有一个字的查询（锁）也能命中包含它的词（互斥锁）
+func indexTokens(text string) []string {
+	return tokenize(text, true)
+}
+
+// tokenize 切分文本，unigrams 为 true 时中日韩文字同时输出单字
+func tokenize(text string, unigrams bool) []string {
+	var tokens []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isCJK(r):
+			j := i
+			for j < len(runes) && isCJK(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, cjkBigrams(runes[i:j])...)
+			if unigrams && j-i > 1 {
+				for _, c := range runes[i:j] {
+					tokens = append(tokens, string(c))
+				}
+			}
+			i = j
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) && !isCJK(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, wordTokens(string(runes[i:j]))...)
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// cjkBigrams 把一段连续的中日韩文字切分为二元组
+func cjkBigrams(run []rune) []string {
+	if len(run) == 1 {
+		return []string{string(run)}
+	}
+	tokens := make([]string, 0, len(run)-1)
+	for i := 0; i+1 < len(run); i++ {
+		tokens = append(tokens, string(run[i:i+2]))
+	}
+	return tokens
+}
+
+// wordTokens 返回单词本身以及驼峰、下划线拆分出的部分，全部转为小写
+func wordTokens(word string) []string {
+	lower := strings.ToLower(word)
+	tokens := []string{lower}
+
+	var parts []string
+	for _, part := range strings.Split(word, "_") {
+		parts = append(parts, splitCamel(part)...)
+	}
+	if len(parts) > 1 {
+		for _, p := range parts {
+			if p = strings.ToLower(p); p != "" && p != lower {
+				tokens = append(tokens, p)
+			}
+		}
+	}
+	return tokens
+}
+
+// splitCamel 按大小写边界拆分驼峰命名，连续大写视为一个缩写（HTTPServer -> HTTP, Server）
+func splitCamel(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := unicode.IsLower(prev) && unicode.IsUpper(cur) ||
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) ||
+			unicode.IsDigit(prev) != unicode.IsDigit(cur)
+		if boundary {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		parts = append(parts, string(runes[start:]))
+	}
+	return parts
+}
+
+// isWordRune 单词中允许出现的字符
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isCJK 是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"  ,.;  ", nil},
+		{"Hello World", []string{"hello", "world"}},
+		{"WithTimeout", []string{"withtimeout", "with", "timeout"}},
+		{"HTTPServer", []string{"httpserver", "http", "server"}},
+		{"max_retry_count", []string{"max_retry_count", "max", "retry", "count"}},
+		{"utf8Decode", []string{"utf8decode", "utf", "8", "decode"}},
+		{"学习要点", []string{"学习", "习要", "要点"}},
+		{"锁", []string{"锁"}},
+		{"互斥锁Mutex的用法", []string{"互斥", "斥锁", "mutex", "的用", "用法"}},
+		{"切片 和 map", []string{"切片", "和", "map"}},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIndexTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"锁", []string{"锁"}},
+		{"互斥锁", []string{"互斥", "斥锁", "互", "斥", "锁"}},
+		{"Go 通道", []string{"go", "通道", "通", "道"}},
+	}
+	for _, tt := range tests {
+		if got := indexTokens(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("indexTokens(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package service
 
 import (
-	"errors"
+	"go-web-api-study/internal/apperr"
 	"go-web-api-study/internal/model"
+	"sync"
 	"time"
 )
 
+// 用户服务错误，类别决定状态码，消息按请求语言翻译（见 internal/apperr）
+var (
+	ErrUserNotFound       = apperr.New(apperr.NotFound, "user_not_found", "user.not_found")
+	ErrUsernameTaken      = apperr.New(apperr.Conflict, "username_taken", "user.username_taken")
+	ErrEmailTaken         = apperr.New(apperr.Conflict, "email_taken", "user.email_taken")
+	ErrInvalidCredentials = apperr.New(apperr.Unauthorized, "invalid_credentials", "user.invalid_credentials")
+)
+
 // UserService 用户服务接口
 type UserService interface {
 	CreateUser(req model.CreateUserRequest) (*model.User, error)
@@ -16,34 +25,40 @@ type UserService interface {
 	Login(req model.LoginRequest) (*model.LoginResponse, error)
 }
 
-// userService 用户服务实现
+// userService 用户服务实现，可以被多个请求并发调用
 type userService struct {
 	// 这里将来会添加数据库连接
-	users []model.User // 临时使用内存存储
+	mu     sync.RWMutex
+	users  []model.User // 临时使用内存存储
+	nextID int          // 删除用户后 ID 也不会复用
 }
 
 // NewUserService 创建用户服务实例
 func NewUserService() UserService {
 	return &userService{
-		users: make([]model.User, 0),
+		users:  make([]model.User, 0),
+		nextID: 1,
 	}
 }
 
 // CreateUser 创建用户
 func (s *userService) CreateUser(req model.CreateUserRequest) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// 检查用户名是否已存在
 	for _, user := range s.users {
 		if user.Username == req.Username {
-			return nil, errors.New("用户名已存在")
+			return nil, ErrUsernameTaken
 		}
 		if user.Email == req.Email {
-			return nil, errors.New("邮箱已存在")
+			return nil, ErrEmailTaken
 		}
 	}
 	
 	// 创建新用户
 	user := model.User{
-		ID:        len(s.users) + 1,
+		ID:        s.nextID,
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  req.Password, // 实际项目中需要加密
@@ -51,68 +66,101 @@ func (s *userService) CreateUser(req model.CreateUserRequest) (*model.User, erro
 		UpdatedAt: time.Now(),
 	}
 	
+	s.nextID++
 	s.users = append(s.users, user)
 	return &user, nil
 }
 
 // GetUserByID 根据ID获取用户
 func (s *userService) GetUserByID(id int) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	for _, user := range s.users {
 		if user.ID == id {
 			return &user, nil
 		}
 	}
-	return nil, errors.New("用户不存在")
+	return nil, ErrUserNotFound
 }
 
 // GetUserByUsername 根据用户名获取用户
 func (s *userService) GetUserByUsername(username string) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	for _, user := range s.users {
 		if user.Username == username {
 			return &user, nil
 		}
 	}
-	return nil, errors.New("用户不存在")
+	return nil, ErrUserNotFound
 }
 
 // UpdateUser 更新用户信息
 func (s *userService) UpdateUser(id int, req model.UpdateUserRequest) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 先确认用户存在，再检查新的用户名和邮箱不能与其他用户重复
+	index := -1
 	for i, user := range s.users {
 		if user.ID == id {
-			if req.Username != "" {
-				s.users[i].Username = req.Username
-			}
-			if req.Email != "" {
-				s.users[i].Email = req.Email
-			}
-			s.users[i].UpdatedAt = time.Now()
-			return &s.users[i], nil
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrUserNotFound
+	}
+
+	for _, user := range s.users {
+		if user.ID == id {
+			continue
+		}
+		if req.Username != "" && user.Username == req.Username {
+			return nil, ErrUsernameTaken
+		}
+		if req.Email != "" && user.Email == req.Email {
+			return nil, ErrEmailTaken
 		}
 	}
-	return nil, errors.New("用户不存在")
+
+	if req.Username != "" {
+		s.users[index].Username = req.Username
+	}
+	if req.Email != "" {
+		s.users[index].Email = req.Email
+	}
+	s.users[index].UpdatedAt = time.Now()
+	updated := s.users[index]
+	return &updated, nil
 }
 
 // DeleteUser 删除用户
 func (s *userService) DeleteUser(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for i, user := range s.users {
 		if user.ID == id {
 			s.users = append(s.users[:i], s.users[i+1:]...)
 			return nil
 		}
 	}
-	return errors.New("用户不存在")
+	return ErrUserNotFound
 }
 
 // Login 用户登录
 func (s *userService) Login(req model.LoginRequest) (*model.LoginResponse, error) {
 	user, err := s.GetUserByUsername(req.Username)
 	if err != nil {
-		return nil, errors.New("用户名或密码错误")
+		return nil, ErrInvalidCredentials
 	}
 	
 	// 简单密码验证（实际项目中需要使用加密验证）
 	if user.Password != req.Password {
-		return nil, errors.New("用户名或密码错误")
+		return nil, ErrInvalidCredentials
 	}
 	
 	// 生成简单token（实际项目中使用JWT）
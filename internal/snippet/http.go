@@ -0,0 +1,166 @@
+package snippet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/viewer"
+	"go-web-api-study/internal/web"
+)
+
+// Prefix 片段页面的路径前缀：/s/{id} 查看，/s/{id}/raw 纯文本
+const Prefix = "/s/"
+
+// CreateRequest 分享片段的请求
+type CreateRequest struct {
+	Content   string `json:"content"`
+	Source    string `json:"source,omitempty"`     // 来源的练习或模块文件，可选
+	ExpiresIn string `json:"expires_in,omitempty"` // Go 时间间隔，如 24h；为空表示永不过期
+}
+
+// CreateResponse 分享结果
+type CreateResponse struct {
+	Meta
+	URL string `json:"url"`
+}
+
+// APIHandler /api/v1/snippets：POST CreateRequest 保存片段，GET ?id= 返回片段内容
+func (s *Store) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			sn, err := s.Get(r.URL.Query().Get("id"))
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			handler.SuccessResponse(w, sn)
+
+		case http.MethodPost:
+			var req CreateRequest
+			// JSON 转义会让内容变长，请求体上限留出余量，内容本身的大小在 Save 中检查
+			body := http.MaxBytesReader(w, r.Body, int64(s.cfg.MaxSize)*2+4096)
+			if err := json.NewDecoder(body).Decode(&req); err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writeError(w, r, ErrTooLarge.With(s.cfg.MaxSize))
+					return
+				}
+				handler.LocalizedErrorResponse(w, r, http.StatusBadRequest, "request.malformed")
+				return
+			}
+			var ttl time.Duration
+			if req.ExpiresIn != "" {
+				d, err := time.ParseDuration(req.ExpiresIn)
+				if err != nil {
+					writeError(w, r, ErrInvalidTTL.With(req.ExpiresIn, s.cfg.MaxTTL).Wrap(err))
+					return
+				}
+				ttl = d
+			}
+			if req.Source != "" && !validSource(req.Source) {
+				writeError(w, r, ErrInvalidSource)
+				return
+			}
+			meta, err := s.Save(req.Content, req.Source, ttl)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			w.Header().Set("Location", Prefix+meta.ID)
+			handler.CreatedResponse(w, CreateResponse{Meta: *meta, URL: Prefix + meta.ID})
+
+		default:
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+		}
+	}
+}
+
+// validSource 来源只能是练习或 gobase 模块中的 Go 文件
+func validSource(p string) bool {
+	return (strings.HasPrefix(p, "exercises/") || strings.HasPrefix(p, "gobase/")) &&
+		strings.HasSuffix(p, ".go") && path.Clean(p) == p && !strings.Contains(p, "..")
+}
+
+// writeError 把片段错误映射为状态码，apperr 类别之外的状态码在这里单独处理
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrExpired):
+		handler.ErrorResponseFrom(w, r, http.StatusGone, err)
+	case errors.Is(err, ErrTooLarge):
+		handler.ErrorResponseFrom(w, r, http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, ErrStoreFull):
+		handler.ErrorResponseFrom(w, r, http.StatusInsufficientStorage, err)
+	default:
+		handler.WriteError(w, r, err)
+	}
+}
+
+// snippetPage 片段页面的数据
+type snippetPage struct {
+	*Snippet
+	URL         string
+	SourceRoute string          // 来源文件的源码页面
+	Command     string          // 下载后在本地运行的命令
+	Lines       []template.HTML // 高亮后的每一行
+}
+
+// PageHandler GET /s/{id} 用源码查看器显示片段；片段只能查看，不在服务器上运行
+func (s *Store) PageHandler(pages *web.Renderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sn, ok := s.lookup(w, r)
+		if !ok {
+			return
+		}
+
+		data := snippetPage{
+			Snippet:     sn,
+			URL:         Prefix + sn.ID,
+			SourceRoute: "/" + strings.TrimSuffix(sn.Source, ".go"),
+			Command:     fmt.Sprintf("curl -s http://%s%s%s/raw > snippet.go && go run snippet.go", r.Host, Prefix, sn.ID),
+		}
+		for _, line := range viewer.Highlight(sn.ID+".go", []byte(sn.Content), nil) {
+			// viewer 返回的每一行都已转义
+			data.Lines = append(data.Lines, template.HTML(line))
+		}
+		pages.Render(w, r, "snippet", data)
+	}
+}
+
+// RawHandler GET /s/{id}/raw 返回片段的纯文本
+func (s *Store) RawHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sn, ok := s.lookup(w, r)
+		if !ok {
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(sn.Content))
+	}
+}
+
+// lookup 读取路径参数 id 对应的片段，失败时写入 404、410 或 500 并返回 false
+func (s *Store) lookup(w http.ResponseWriter, r *http.Request) (*Snippet, bool) {
+	sn, err := s.Get(r.PathValue("id"))
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.NotFound(w, r)
+		return nil, false
+	case errors.Is(err, ErrExpired):
+		http.Error(w, apperr.From(err).Message(i18n.Of(r)), http.StatusGone)
+		return nil, false
+	case err != nil:
+		handler.WritePageError(w, r, err)
+		return nil, false
+	}
+	return sn, true
+}
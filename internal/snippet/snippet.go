@@ -0,0 +1,305 @@
+// Package snippet 保存分享的 Go 代码片段：ID 由内容哈希得到，相同内容总是同一个链接，
+// 片段保存在本地目录中，有单个片段和总大小的上限，可以设置过期时间
+package snippet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go-web-api-study/internal/apperr"
+	"go-web-api-study/internal/fsutil"
+)
+
+// Dir 片段所在目录，相对仓库根目录；每个片段是 <id>.go 源码和 <id>.json 元数据
+const Dir = ".study/snippets"
+
+// 默认限制
+const (
+	DefaultMaxSize  = 64 << 10 // 单个片段 64KB
+	DefaultMaxTotal = 16 << 20 // 全部片段 16MB
+	DefaultMaxTTL   = 30 * 24 * time.Hour
+)
+
+// 内容哈希的十六进制前缀长度，与已有的不同片段冲突时加长
+const (
+	idLength    = 10
+	maxIDLength = sha256.Size * 2
+)
+
+// 片段错误，消息按请求语言翻译（见 internal/apperr）；过期、太大和存储已满在 writeError 中另有状态码
+var (
+	// ErrNotFound 片段不存在
+	ErrNotFound = apperr.New(apperr.NotFound, "snippet_not_found", "snippet.not_found")
+	// ErrExpired 片段已过期
+	ErrExpired = apperr.New(apperr.NotFound, "snippet_expired", "snippet.expired")
+	// ErrEmpty 片段内容为空
+	ErrEmpty = apperr.New(apperr.Validation, "snippet_empty", "snippet.empty")
+	// ErrInvalid 片段内容不是 UTF-8 文本
+	ErrInvalid = apperr.New(apperr.Validation, "snippet_invalid", "snippet.invalid")
+	// ErrTooLarge 片段超过单个大小上限，参数为上限
+	ErrTooLarge = apperr.New(apperr.Validation, "snippet_too_large", "snippet.too_large")
+	// ErrStoreFull 全部片段超过总大小上限，参数为已使用的大小和上限
+	ErrStoreFull = apperr.New(apperr.RateLimited, "snippet_store_full", "snippet.store_full")
+	// ErrInvalidTTL 过期时间无法解析、为负数或超过上限，参数为过期时间和上限
+	ErrInvalidTTL = apperr.New(apperr.Validation, "invalid_ttl", "snippet.invalid_ttl")
+	// ErrInvalidSource 来源不是练习或 gobase 模块中的 Go 文件
+	ErrInvalidSource = apperr.New(apperr.Validation, "invalid_source", "snippet.invalid_source")
+)
+
+var idPattern = regexp.MustCompile(`^[0-9a-f]{10,64}$`)
+
+// ValidID ID 只能是内容哈希的十六进制前缀，用作文件名
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// Config 存储配置，零值字段使用默认限制
+type Config struct {
+	Root     string        // 仓库根目录
+	MaxSize  int           // 单个片段的最大字节数
+	MaxTotal int64         // 全部片段源码的最大字节数，超过时先清理过期片段
+	MaxTTL   time.Duration // 过期时间上限
+}
+
+// Meta 片段的元数据
+type Meta struct {
+	ID      string     `json:"id"`
+	Source  string     `json:"source,omitempty"` // 来源文件，如 exercises/day03/01_slices.go
+	Size    int        `json:"size"`
+	Created time.Time  `json:"created"`
+	Expires *time.Time `json:"expires,omitempty"` // 为空表示永不过期
+}
+
+// Expired 片段在 now 时是否已过期
+func (m *Meta) Expired(now time.Time) bool {
+	return m.Expires != nil && !now.Before(*m.Expires)
+}
+
+// Snippet 片段的元数据和内容
+type Snippet struct {
+	Meta
+	Content string `json:"content"`
+}
+
+// Store 本地片段存储
+type Store struct {
+	cfg Config
+	dir string
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// New 创建片段存储
+func New(cfg Config) *Store {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	if cfg.MaxTotal <= 0 {
+		cfg.MaxTotal = DefaultMaxTotal
+	}
+	if cfg.MaxTTL <= 0 {
+		cfg.MaxTTL = DefaultMaxTTL
+	}
+	return &Store{cfg: cfg, dir: filepath.Join(cfg.Root, filepath.FromSlash(Dir)), now: time.Now}
+}
+
+// MaxSize 单个片段的最大字节数
+func (s *Store) MaxSize() int {
+	return s.cfg.MaxSize
+}
+
+// Save 保存片段并返回元数据；ttl 为 0 表示永不过期。
+// 相同内容已存在时复用原来的 ID，过期时间取两者中较晚的一个（永不过期优先）
+func (s *Store) Save(content, source string, ttl time.Duration) (*Meta, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	switch {
+	case strings.TrimSpace(content) == "":
+		return nil, ErrEmpty
+	case !utf8.ValidString(content):
+		return nil, ErrInvalid
+	case len(content) > s.cfg.MaxSize:
+		return nil, ErrTooLarge.With(s.cfg.MaxSize)
+	case ttl < 0 || ttl > s.cfg.MaxTTL:
+		return nil, ErrInvalidTTL.With(ttl.String(), s.cfg.MaxTTL)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	meta := &Meta{Source: source, Size: len(content), Created: now}
+	if ttl > 0 {
+		expires := now.Add(ttl)
+		meta.Expires = &expires
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+	for n := idLength; ; n += 2 {
+		if n > maxIDLength {
+			return nil, fmt.Errorf("无法为代码片段分配 ID")
+		}
+		id := hash[:n]
+		existing, err := s.read(id)
+		if errors.Is(err, ErrNotFound) {
+			meta.ID = id
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.Content != content {
+			continue // 哈希前缀冲突，加长 ID
+		}
+		// 相同内容：过期的片段重新开始计时，否则保留创建时间并延长过期时间
+		if !existing.Expired(now) {
+			meta.Created = existing.Created
+			if existing.Expires == nil || (meta.Expires != nil && existing.Expires.After(*meta.Expires)) {
+				meta.Expires = existing.Expires
+			}
+			if meta.Source == "" {
+				meta.Source = existing.Source
+			}
+		}
+		meta.ID = id
+		return meta, s.writeMeta(meta)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := s.reserve(int64(len(content))); err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(s.sourcePath(meta.ID), []byte(content), 0o644); err != nil {
+		return nil, err
+	}
+	return meta, s.writeMeta(meta)
+}
+
+// Get 读取片段，过期的片段会被删除
+func (s *Store) Get(id string) (*Snippet, error) {
+	if !ValidID(id) {
+		return nil, ErrNotFound
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sn, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	if sn.Expired(s.now()) {
+		s.remove(id)
+		return nil, ErrExpired
+	}
+	return sn, nil
+}
+
+// Purge 删除全部过期片段，返回删除的数量
+func (s *Store) Purge() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed, _, err := s.purge()
+	return removed, err
+}
+
+// reserve 确认再写入 size 字节不会超过总大小上限，超过时先清理过期片段
+func (s *Store) reserve(size int64) error {
+	_, total, err := s.purge()
+	if err != nil {
+		return err
+	}
+	if total+size > s.cfg.MaxTotal {
+		return ErrStoreFull.With(total, s.cfg.MaxTotal)
+	}
+	return nil
+}
+
+// purge 删除过期片段，返回删除的数量和剩余片段源码的总字节数
+func (s *Store) purge() (removed int, total int64, err error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return 0, 0, err
+	}
+	now := s.now()
+	for _, f := range files {
+		id := strings.TrimSuffix(filepath.Base(f), ".json")
+		meta, err := s.readMeta(id)
+		if err != nil {
+			continue // 损坏的元数据不计入也不删除，方便手工检查
+		}
+		if meta.Expired(now) {
+			s.remove(id)
+			removed++
+			continue
+		}
+		total += int64(meta.Size)
+	}
+	return removed, total, nil
+}
+
+// read 读取片段的元数据和内容，不检查过期
+func (s *Store) read(id string) (*Snippet, error) {
+	meta, err := s.readMeta(id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(s.sourcePath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Snippet{Meta: *meta, Content: string(content)}, nil
+}
+
+// readMeta 读取片段的元数据
+func (s *Store) readMeta(id string) (*Meta, error) {
+	data, err := os.ReadFile(s.metaPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", s.metaPath(id), err)
+	}
+	return &meta, nil
+}
+
+// writeMeta 保存片段的元数据
+func (s *Store) writeMeta(meta *Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(s.metaPath(meta.ID), data, 0o644)
+}
+
+// remove 删除片段的源码和元数据
+func (s *Store) remove(id string) {
+	os.Remove(s.metaPath(id))
+	os.Remove(s.sourcePath(id))
+}
+
+func (s *Store) sourcePath(id string) string {
+	return filepath.Join(s.dir, id+".go")
+}
+
+func (s *Store) metaPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
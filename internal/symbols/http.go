@@ -0,0 +1,28 @@
+package symbols
+
+import (
+	"net/http"
+
+	"go-web-api-study/internal/handler"
+)
+
+// APIHandler /api/v1/symbols：?name= 按名称查找定义，?path= 返回文件大纲，都不带时返回全部符号
+func (idx *Index) APIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handler.LocalizedErrorResponse(w, r, http.StatusMethodNotAllowed, "request.method_not_allowed")
+			return
+		}
+		idx.Refresh()
+
+		query := r.URL.Query()
+		switch {
+		case query.Get("name") != "":
+			handler.SuccessResponse(w, idx.Lookup(query.Get("name")))
+		case query.Get("path") != "":
+			handler.SuccessResponse(w, idx.Outline(query.Get("path")))
+		default:
+			handler.SuccessResponse(w, idx.All())
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package symbols
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-web-api-study/internal/catalog"
+)
+
+// Kind 符号类别
+type Kind string
+
+const (
+	KindFunc   Kind = "func"
+	KindMethod Kind = "method"
+	KindType   Kind = "type"
+)
+
+// Symbol 源码中的一个顶层定义
+type Symbol struct {
+	Name      string `json:"name"`
+	Kind      Kind   `json:"kind"`
+	Recv      string `json:"recv,omitempty"` // 方法的接收者类型名
+	Path      string `json:"path"`           // 相对仓库根目录的文件路径
+	Route     string `json:"route"`          // 文件的 Web 访问路径
+	Line      int    `json:"line"`
+	Doc       string `json:"doc,omitempty"`
+	Signature string `json:"signature"`
+}
+
+// QualifiedName 方法返回 Recv.Name，其余返回 Name
+func (s Symbol) QualifiedName() string {
+	if s.Recv != "" {
+		return s.Recv + "." + s.Name
+	}
+	return s.Name
+}
+
+// URL 返回跳转到定义所在行的链接
+func (s Symbol) URL() string {
+	return s.Route + "#L" + strconv.Itoa(s.Line)
+}
+
+// fileSymbols 单个文件的符号，按修改时间缓存
+type fileSymbols struct {
+	modTime time.Time
+	size    int64
+	symbols []Symbol
+}
+
+// Index gobase 和 exercises 中全部 Go 文件的符号索引，文件修改后按需重新解析
+type Index struct {
+	cat *catalog.Catalog
+
+	mu     sync.RWMutex
+	files  map[string]fileSymbols // 按文件路径
+	byName map[string][]Symbol    // 按符号名（方法按方法名）
+}
+
+// NewIndex 创建索引并立即构建一次
+func NewIndex(cat *catalog.Catalog) *Index {
+	idx := &Index{cat: cat, files: make(map[string]fileSymbols)}
+	idx.Refresh()
+	return idx
+}
+
+// Refresh 重新解析有改动的文件，删除已不在目录中的文件
+func (idx *Index) Refresh() {
+	var entries []catalog.Entry
+	for _, e := range idx.cat.Entries("") {
+		if e.Kind == catalog.KindGobase || e.Kind == catalog.KindExercise {
+			entries = append(entries, e)
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	changed := false
+	alive := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		alive[e.Path] = true
+		info, err := os.Stat(filepath.Join(idx.cat.Root(), e.Path))
+		if err != nil {
+			continue
+		}
+		if cached, ok := idx.files[e.Path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			continue
+		}
+		idx.files[e.Path] = fileSymbols{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			symbols: parseFile(idx.cat.Root(), e),
+		}
+		changed = true
+	}
+	for path := range idx.files {
+		if !alive[path] {
+			delete(idx.files, path)
+			changed = true
+		}
+	}
+
+	if changed || idx.byName == nil {
+		idx.rebuildNames()
+	}
+}
+
+// rebuildNames 重建按名称的索引，调用方需持有写锁
+func (idx *Index) rebuildNames() {
+	byName := make(map[string][]Symbol)
+	for _, fs := range idx.files {
+		for _, s := range fs.symbols {
+			byName[s.Name] = append(byName[s.Name], s)
+		}
+	}
+	for _, list := range byName {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Path != list[j].Path {
+				return list[i].Path < list[j].Path
+			}
+			return list[i].Line < list[j].Line
+		})
+	}
+	idx.byName = byName
+}
+
+// Lookup 返回所有名为 name 的定义（包括同名方法）
+func (idx *Index) Lookup(name string) []Symbol {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return append([]Symbol(nil), idx.byName[name]...)
+}
+
+// Outline 返回单个文件中的符号，按行号排序
+func (idx *Index) Outline(path string) []Symbol {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return append([]Symbol(nil), idx.files[path].symbols...)
+}
+
+// All 返回全部符号，按文件和行号排序
+func (idx *Index) All() []Symbol {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var all []Symbol
+	for _, fs := range idx.files {
+		all = append(all, fs.symbols...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Path != all[j].Path {
+			return all[i].Path < all[j].Path
+		}
+		return all[i].Line < all[j].Line
+	})
+	return all
+}
+
+// Linker 返回给源码查看器使用的标识符链接函数：
+// 同一文件中有定义时跳到该行，只在其他文件中有定义时跳到候选列表，
+// 定义处本身在存在同名定义时也链接到候选列表
+func (idx *Index) Linker(path string) func(name string, line int) string {
+	return func(name string, line int) string {
+		candidates := idx.Lookup(name)
+		if len(candidates) == 0 {
+			return ""
+		}
+		for _, s := range candidates {
+			if s.Path == path && s.Line == line {
+				if len(candidates) > 1 {
+					return "/symbols?name=" + name
+				}
+				return ""
+			}
+		}
+		var local []Symbol
+		for _, s := range candidates {
+			if s.Path == path {
+				local = append(local, s)
+			}
+		}
+		// 同一文件中同名的多个方法无法仅凭名称区分，交给候选列表
+		if len(local) == 1 {
+			return "#L" + strconv.Itoa(local[0].Line)
+		}
+		return "/symbols?name=" + name
+	}
+}
+
+// parseFile 解析文件中的顶层函数、方法和类型
+func parseFile(root string, e catalog.Entry) []Symbol {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(root, e.Path), nil, parser.ParseComments)
+	if f == nil && err != nil {
+		return nil
+	}
+
+	var result []Symbol
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			s := Symbol{
+				Name:      d.Name.Name,
+				Kind:      KindFunc,
+				Path:      e.Path,
+				Route:     e.Route,
+				Line:      fset.Position(d.Name.Pos()).Line,
+				Doc:       strings.TrimSpace(d.Doc.Text()),
+				Signature: funcSignature(fset, d),
+			}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				s.Kind = KindMethod
+				s.Recv = recvTypeName(d.Recv.List[0].Type)
+			}
+			result = append(result, s)
+
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(d.Specs) == 1 {
+					doc = d.Doc
+				}
+				result = append(result, Symbol{
+					Name:      ts.Name.Name,
+					Kind:      KindType,
+					Path:      e.Path,
+					Route:     e.Route,
+					Line:      fset.Position(ts.Name.Pos()).Line,
+					Doc:       strings.TrimSpace(doc.Text()),
+					Signature: typeSignature(fset, ts),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// funcSignature 打印不含函数体的函数声明
+func funcSignature(fset *token.FileSet, d *ast.FuncDecl) string {
+	sig := *d
+	sig.Doc = nil
+	sig.Body = nil
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, &sig); err != nil {
+		return "func " + d.Name.Name
+	}
+	return buf.String()
+}
+
+// typeSignature 返回类型声明的概要，结构体和接口不展开字段
+func typeSignature(fset *token.FileSet, ts *ast.TypeSpec) string {
+	spec := *ts
+	spec.Doc, spec.Comment = nil, nil
+	folded := false
+	switch ts.Type.(type) {
+	case *ast.StructType:
+		spec.Type = &ast.StructType{Fields: &ast.FieldList{}}
+		folded = true
+	case *ast.InterfaceType:
+		spec.Type = &ast.InterfaceType{Methods: &ast.FieldList{}}
+		folded = true
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, &spec); err != nil {
+		return "type " + ts.Name.Name
+	}
+	sig := "type " + buf.String()
+	if i := strings.LastIndex(sig, "{"); folded && i >= 0 {
+		sig = sig[:i] + "{ ... }"
+	}
+	return sig
+}
+
+// recvTypeName 从接收者类型表达式中取出类型名，如 *Stack[T] -> Stack
+func recvTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(t.X)
+	case *ast.IndexExpr:
+		return recvTypeName(t.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
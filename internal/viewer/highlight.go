@@ -0,0 +1,246 @@
+package viewer
+
+import (
+	"bytes"
+	"go/scanner"
+	"go/token"
+	"html"
+	"strings"
+)
+
+// 高亮使用的 CSS 类名
+const (
+	classKeyword = "kw"  // 关键字
+	classBuiltin = "bi"  // 预声明标识符：内置类型、函数和常量
+	classString  = "str" // 字符串和字符字面量
+	classNumber  = "num" // 数字字面量
+	classComment = "com" // 注释
+)
+
+// builtins Go 预声明的标识符
+var builtins = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true, "copy": true,
+	"delete": true, "imag": true, "len": true, "make": true, "max": true, "min": true, "new": true,
+	"panic": true, "print": true, "println": true, "real": true, "recover": true,
+}
+
+// segment 一段带（或不带）高亮类名的源码
+type segment struct {
+	class string
+	text  string
+	href  string // 非空时整段包在链接中
+}
+
+// LinkFunc 返回标识符 name（位于第 line 行）的跳转链接，返回空串表示不加链接
+type LinkFunc func(name string, line int) string
+
+// HighlightGo 使用 go/scanner 对 Go 源码做词法高亮，返回每一行已转义的 HTML
+func HighlightGo(src []byte) []string {
+	return highlightGo(src, nil)
+}
+
+// highlightGo 词法高亮，link 非空时为标识符加上跳转链接
+func highlightGo(src []byte, link LinkFunc) []string {
+	src = normalizeNewlines(src)
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	// 语法错误不影响高亮，忽略错误回调
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	var segments []segment
+	offset := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// 自动插入的分号没有对应的源码文本
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		start := file.Offset(pos)
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		end := start + len(text)
+		if start < offset || end > len(src) {
+			continue
+		}
+
+		if start > offset {
+			segments = append(segments, segment{text: string(src[offset:start])})
+		}
+		seg := segment{class: goTokenClass(tok, lit), text: string(src[start:end])}
+		if link != nil && tok == token.IDENT && seg.class == "" {
+			seg.href = link(lit, file.Line(pos))
+		}
+		segments = append(segments, seg)
+		offset = end
+	}
+	if offset < len(src) {
+		segments = append(segments, segment{text: string(src[offset:])})
+	}
+	return splitLines(segments)
+}
+
+// goTokenClass 返回词法单元对应的高亮类名
+func goTokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok.IsKeyword():
+		return classKeyword
+	case tok == token.COMMENT:
+		return classComment
+	case tok == token.STRING || tok == token.CHAR:
+		return classString
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return classNumber
+	case tok == token.IDENT && builtins[lit]:
+		return classBuiltin
+	}
+	return ""
+}
+
+// HighlightMarkdown 对 Markdown 做按行高亮，```go 代码块内使用 Go 高亮
+func HighlightMarkdown(src []byte) []string {
+	lines := strings.Split(string(normalizeNewlines(src)), "\n")
+	result := make([]string, 0, len(lines))
+
+	var (
+		inFence   bool
+		fenceLang string
+		block     []string
+	)
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		if fenceLang == "go" {
+			result = append(result, HighlightGo([]byte(strings.Join(block, "\n")))...)
+		} else {
+			for _, line := range block {
+				result = append(result, wrap("md-code", line))
+			}
+		}
+		block = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			if inFence {
+				flush()
+			} else {
+				fenceLang = strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			}
+			inFence = !inFence
+			result = append(result, wrap("md-fence", line))
+			continue
+		}
+		if inFence {
+			block = append(block, line)
+			continue
+		}
+		result = append(result, markdownLine(line))
+	}
+	flush()
+	return result
+}
+
+// markdownLine 高亮 Markdown 的单行
+func markdownLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "#"):
+		return wrap("md-h", line)
+	case strings.HasPrefix(trimmed, "- [x]"), strings.HasPrefix(trimmed, "- [X]"):
+		return wrap("md-done", line)
+	case strings.HasPrefix(trimmed, "- [ ]"):
+		return wrap("md-todo", line)
+	case strings.HasPrefix(trimmed, ">"):
+		return wrap("md-quote", line)
+	case strings.HasPrefix(trimmed, "|"):
+		return wrap("md-table", line)
+	}
+	return inlineCode(line)
+}
+
+// inlineCode 高亮行内的 `code` 片段
+func inlineCode(line string) string {
+	parts := strings.Split(line, "`")
+	if len(parts) < 3 {
+		return html.EscapeString(line)
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		switch {
+		case i%2 == 0:
+			b.WriteString(html.EscapeString(part))
+		case i == len(parts)-1:
+			// 未闭合的反引号按普通文本输出
+			b.WriteString("`" + html.EscapeString(part))
+		default:
+			b.WriteString(wrap("md-inline", "`"+part+"`"))
+		}
+	}
+	return b.String()
+}
+
+// HighlightPlain 不做高亮，仅转义并按行切分
+func HighlightPlain(src []byte) []string {
+	lines := strings.Split(string(normalizeNewlines(src)), "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return lines
+}
+
+// splitLines 把高亮片段按换行切分成行，跨行的注释和原始字符串在每行重新打开 span
+func splitLines(segments []segment) []string {
+	var (
+		lines   []string
+		current strings.Builder
+	)
+	for _, seg := range segments {
+		parts := strings.Split(seg.text, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				lines = append(lines, current.String())
+				current.Reset()
+			}
+			if part == "" {
+				continue
+			}
+			switch {
+			case seg.href != "":
+				current.WriteString(`<a class="sym" href="` + html.EscapeString(seg.href) + `">` + html.EscapeString(part) + `</a>`)
+			case seg.class == "":
+				current.WriteString(html.EscapeString(part))
+			default:
+				current.WriteString(wrap(seg.class, part))
+			}
+		}
+	}
+	return append(lines, current.String())
+}
+
+// wrap 转义文本并包上带类名的 span
+func wrap(class, text string) string {
+	if text == "" {
+		return ""
+	}
+	return `<span class="` + class + `">` + html.EscapeString(text) + `</span>`
+}
+
+// normalizeNewlines 统一换行符，保证词法单元的长度与源码一致
+func normalizeNewlines(src []byte) []byte {
+	return bytes.ReplaceAll(src, []byte("\r\n"), []byte("\n"))
+}
@@ -0,0 +1,135 @@
+package viewer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHighlightEscapesHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		src      string
+		want     []string
+	}{
+		{
+			name:     "go string literal",
+			filename: "a.go",
+			src:      `s := "<script>&'"`,
+			want:     []string{`s := <span class="str">&#34;&lt;script&gt;&amp;&#39;&#34;</span>`},
+		},
+		{
+			name:     "go comment",
+			filename: "a.go",
+			src:      "// a < b && c > d",
+			want:     []string{`<span class="com">// a &lt; b &amp;&amp; c &gt; d</span>`},
+		},
+		{
+			name:     "go operators",
+			filename: "a.go",
+			src:      "x := a<b && c>d",
+			want:     []string{`x := a&lt;b &amp;&amp; c&gt;d`},
+		},
+		{
+			name:     "go invalid tokens",
+			filename: "a.go",
+			src:      "</div><img src=x onerror=alert(1)>",
+			want:     []string{`&lt;/div&gt;&lt;img src=x onerror=alert(<span class="num">1</span>)&gt;`},
+		},
+		{
+			name:     "markdown heading and inline code",
+			filename: "a.md",
+			src:      "# <h1>\nuse `<b>` & <i>",
+			want: []string{
+				`<span class="md-h"># &lt;h1&gt;</span>`,
+				`use <span class="md-inline">` + "`&lt;b&gt;`" + `</span> &amp; &lt;i&gt;`,
+			},
+		},
+		{
+			name:     "markdown unclosed backtick",
+			filename: "a.md",
+			src:      "a `<b>` c `<d>",
+			want:     []string{`a <span class="md-inline">` + "`&lt;b&gt;`" + `</span> c ` + "`&lt;d&gt;"},
+		},
+		{
+			name:     "markdown code block",
+			filename: "a.md",
+			src:      "```html\n<p>\"x\"</p>\n```",
+			want: []string{
+				`<span class="md-fence">` + "```html" + `</span>`,
+				`<span class="md-code">&lt;p&gt;&#34;x&#34;&lt;/p&gt;</span>`,
+				`<span class="md-fence">` + "```" + `</span>`,
+			},
+		},
+		{
+			name:     "plain text",
+			filename: "a.txt",
+			src:      "<a href=\"x\">&</a>\n",
+			want:     []string{`&lt;a href=&#34;x&#34;&gt;&amp;&lt;/a&gt;`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Highlight(tt.filename, []byte(tt.src), nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Highlight() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightGoLines(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "block comment spans lines",
+			src:  "/* a\n<b> */ x",
+			want: []string{`<span class="com">/* a</span>`, `<span class="com">&lt;b&gt; */</span> x`},
+		},
+		{
+			name: "raw string spans lines",
+			src:  "s := `<\n>`",
+			want: []string{`s := <span class="str">` + "`&lt;" + `</span>`, `<span class="str">&gt;` + "`" + `</span>`},
+		},
+		{
+			name: "crlf newlines",
+			src:  "var x int\r\nreturn nil\r\n",
+			want: []string{
+				`<span class="kw">var</span> x <span class="bi">int</span>`,
+				`<span class="kw">return</span> <span class="bi">nil</span>`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Highlight("a.go", []byte(tt.src), nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Highlight() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightGoLinksEscapeHref(t *testing.T) {
+	link := func(name string, line int) string {
+		if name == "len" {
+			t.Errorf("link called for builtin %s", name)
+		}
+		if name != "target" {
+			return ""
+		}
+		return `/x?a=1&b="<` + name + `>"#L` + strings.Repeat("1", line)
+	}
+	got := Highlight("a.go", []byte("\nfoo(target, len(x))"), link)
+	want := []string{
+		"",
+		`foo(<a class="sym" href="/x?a=1&amp;b=&#34;&lt;target&gt;&#34;#L11">target</a>, <span class="bi">len</span>(x))`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Highlight() =\n%q\nwant\n%q", got, want)
+	}
+}
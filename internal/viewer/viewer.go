@@ -0,0 +1,25 @@
+package viewer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Highlight 根据文件扩展名选择高亮方式，返回每一行已转义的 HTML；Go 源码中的标识符按 link 加上链接，
+// link 为空时不加链接
+func Highlight(filename string, src []byte, link LinkFunc) []string {
+	var lines []string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".go":
+		lines = highlightGo(src, link)
+	case ".md":
+		lines = HighlightMarkdown(src)
+	default:
+		lines = HighlightPlain(src)
+	}
+	// 文件末尾的换行不单独占一行
+	if n := len(lines); n > 1 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+	return lines
+}
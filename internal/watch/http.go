@@ -0,0 +1,56 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-web-api-study/internal/handler"
+)
+
+// heartbeatInterval SSE 心跳间隔，防止空闲连接被代理断开
+const heartbeatInterval = 15 * time.Second
+
+// Handler 返回文件变化通知接口 GET /api/v1/watch?path=exercises/day03/variables_practice.go，
+// 以 Server-Sent Events 推送 change 事件（data 为 Change JSON）；带 path 时只推送该文件的变化
+func (w *Watcher) Handler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			handler.LocalizedErrorResponse(rw, r, http.StatusInternalServerError, "request.no_streaming")
+			return
+		}
+		path := r.URL.Query().Get("path")
+
+		changes, cancel := w.Subscribe()
+		defer cancel()
+
+		rw.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.Header().Set("X-Accel-Buffering", "no")
+		rw.WriteHeader(http.StatusOK)
+		// 连接断开后浏览器在 retry 毫秒后自动重连
+		fmt.Fprintf(rw, "retry: %d\n\n", w.interval.Milliseconds()*2)
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				fmt.Fprintf(rw, ": ping\n\n")
+				flusher.Flush()
+			case c := <-changes:
+				if path != "" && c.Path != path {
+					continue
+				}
+				data, _ := json.Marshal(c)
+				fmt.Fprintf(rw, "event: change\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Op 文件变化类型
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpModify Op = "modify"
+	OpRemove Op = "remove"
+)
+
+// subscriberBuffer 每个订阅者的缓冲区大小，消费不及时的订阅者会丢弃多余的通知
+const subscriberBuffer = 16
+
+// Change 一次文件变化
+type Change struct {
+	Op   Op        `json:"op"`
+	Path string    `json:"path"` // 相对根目录的路径（使用 /）
+	Time time.Time `json:"time"`
+}
+
+// stamp 用于判断文件是否变化的修改时间和大小
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher 轮询式文件监视器，定期扫描目录并把变化推送给订阅者，不依赖操作系统的文件通知
+type Watcher struct {
+	root     string
+	dirs     []string
+	interval time.Duration
+
+	mu     sync.Mutex
+	files  map[string]stamp
+	subs   map[int]chan Change
+	nextID int
+}
+
+// New 创建监视器，dirs 为相对 root 的目录，每隔 interval 扫描一次
+func New(root string, interval time.Duration, dirs ...string) *Watcher {
+	w := &Watcher{
+		root:     root,
+		dirs:     dirs,
+		interval: interval,
+		subs:     make(map[int]chan Change),
+	}
+	w.files = w.scan()
+	return w
+}
+
+// Run 开始轮询，直到 ctx 结束
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Poll()
+		}
+	}
+}
+
+// Poll 立即扫描一次，通知订阅者并返回发现的变化
+func (w *Watcher) Poll() []Change {
+	current := w.scan()
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changes []Change
+	for path, st := range current {
+		old, ok := w.files[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Op: OpCreate, Path: path, Time: now})
+		case !old.modTime.Equal(st.modTime) || old.size != st.size:
+			changes = append(changes, Change{Op: OpModify, Path: path, Time: now})
+		}
+	}
+	for path := range w.files {
+		if _, ok := current[path]; !ok {
+			changes = append(changes, Change{Op: OpRemove, Path: path, Time: now})
+		}
+	}
+	w.files = current
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	for _, c := range changes {
+		for _, ch := range w.subs {
+			select {
+			case ch <- c:
+			default:
+			}
+		}
+	}
+	return changes
+}
+
+// Subscribe 订阅文件变化，返回的 cancel 用于取消订阅
+func (w *Watcher) Subscribe() (<-chan Change, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	ch := make(chan Change, subscriberBuffer)
+	w.subs[id] = ch
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// scan 遍历被监视的目录，记录每个文件的修改时间和大小
+func (w *Watcher) scan() map[string]stamp {
+	files := make(map[string]stamp)
+	for _, dir := range w.dirs {
+		base := filepath.Join(w.root, dir)
+		// 目录不存在或无法读取时跳过，下一轮再试
+		filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			rel, err := filepath.Rel(w.root, p)
+			if err != nil {
+				return nil
+			}
+			files[filepath.ToSlash(rel)] = stamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return files
+}
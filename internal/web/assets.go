@@ -0,0 +1,130 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go-web-api-study/internal/i18n"
+)
+
+// StaticPrefix 静态资源的 URL 前缀
+const StaticPrefix = "/static/"
+
+// asset 一个嵌入的静态资源
+type asset struct {
+	name   string // 逻辑名，如 style.css、img/1.jpg
+	hashed string // 带内容哈希的文件名，如 style.3fa2b1c0.css
+	etag   string
+	data   []byte
+}
+
+// Assets 嵌入的静态资源：按内容哈希生成文件名，带哈希的 URL 可以长期缓存
+type Assets struct {
+	byName   map[string]*asset
+	byHashed map[string]*asset
+	modTime  time.Time // 所有资源使用进程启动时间作为 Last-Modified
+}
+
+// NewAssets 创建空的资源集合，用 Add 加入文件系统
+func NewAssets() *Assets {
+	return &Assets{byName: make(map[string]*asset), byHashed: make(map[string]*asset), modTime: time.Now()}
+}
+
+// Add 加入 fsys 中的全部文件，逻辑名为 prefix + 文件路径（如 img/1.jpg），后加入的同名资源覆盖先加入的
+func (a *Assets) Add(prefix string, fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:4])
+		ext := path.Ext(p)
+		item := &asset{
+			name:   prefix + p,
+			hashed: prefix + strings.TrimSuffix(p, ext) + "." + hash + ext,
+			etag:   `"` + hex.EncodeToString(sum[:8]) + `"`,
+			data:   data,
+		}
+		if old, ok := a.byName[item.name]; ok {
+			delete(a.byHashed, old.hashed)
+		}
+		a.byName[item.name] = item
+		a.byHashed[item.hashed] = item
+		return nil
+	})
+}
+
+// URL 返回资源带内容哈希的 URL；模板中引用不存在的资源时渲染失败
+func (a *Assets) URL(name string) (string, error) {
+	item, ok := a.byName[name]
+	if !ok {
+		return "", fmt.Errorf("静态资源不存在: %s", name)
+	}
+	return StaticPrefix + item.hashed, nil
+}
+
+// Names 列出全部资源的逻辑名
+func (a *Assets) Names() []string {
+	names := make([]string, 0, len(a.byName))
+	for name := range a.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open 按逻辑名或带哈希的文件名读取资源，返回内容和带哈希的文件名
+func (a *Assets) Open(name string) ([]byte, string, bool) {
+	item, ok := a.byHashed[name]
+	if !ok {
+		item, ok = a.byName[name]
+	}
+	if !ok {
+		return nil, "", false
+	}
+	return item.data, item.hashed, true
+}
+
+// ServeHTTP 提供 /static/ 下的资源：带哈希的文件名缓存一年且不再验证，
+// 逻辑名每次都需要用 ETag 验证
+func (a *Assets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, i18n.T(i18n.Of(r), "request.get_only"), http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, StaticPrefix)
+	item, immutable := a.byHashed[name]
+	if !immutable {
+		var ok bool
+		if item, ok = a.byName[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	if immutable {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.Header().Set("ETag", item.etag)
+	if ct := mime.TypeByExtension(path.Ext(item.name)); ct != "" {
+		w.Header().Set("Content-Type", ct)
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, item.name, a.modTime, bytes.NewReader(item.data))
+}
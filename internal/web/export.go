@@ -0,0 +1,124 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/i18n"
+)
+
+// sitePage 静态站点中的一个页面
+type sitePage struct {
+	route   string // 服务器上的路由，如 /gobase/05_http_basics
+	file    string // 站点中的文件，如 gobase/05_http_basics.html
+	handler http.HandlerFunc
+}
+
+// siteLink 页面中以 / 开头的 href 和 src
+var siteLink = regexp.MustCompile(`(href|src)="(/[^"]*)"`)
+
+// Export 用与服务器相同的处理器渲染首页、练习和模块列表以及目录中每个文件的源码页面，
+// 写入 dir 作为静态站点：站内链接改写为相对路径，静态资源写入 static/，需要服务器的链接保持原样。
+// 返回写入的页面数
+func (p *Pages) Export(dir string, locale i18n.Locale) (int, error) {
+	pages := []sitePage{
+		{route: "/", file: "index.html", handler: p.Home},
+		{route: "/exercises", file: "exercises.html", handler: p.Exercises},
+		{route: "/gobase", file: "gobase.html", handler: p.Gobase},
+	}
+	for _, e := range p.cat.Entries("") {
+		pages = append(pages, sitePage{route: e.Route, file: strings.TrimPrefix(e.Route, "/") + ".html", handler: p.Source(e)})
+	}
+	files := make(map[string]string, len(pages))
+	for _, pg := range pages {
+		files[pg.route] = pg.file
+	}
+	// /exercises/dayNN 在服务器上跳转到当天第一个文件
+	for _, e := range p.cat.Entries(catalog.KindExercise) {
+		route := "/exercises/" + e.Group
+		if _, ok := files[route]; !ok {
+			files[route] = files[p.cat.Day(e.Group)[0].Route]
+		}
+	}
+
+	ctx := i18n.WithLocale(context.Background(), locale)
+	for _, pg := range pages {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pg.route, nil)
+		if err != nil {
+			return 0, err
+		}
+		rec := &pageRecorder{header: make(http.Header), status: http.StatusOK}
+		pg.handler(rec, req)
+		if rec.status != http.StatusOK {
+			return 0, fmt.Errorf("渲染 %s 失败: %d %s", pg.route, rec.status, strings.TrimSpace(rec.body.String()))
+		}
+		out := siteLink.ReplaceAllFunc(rec.body.Bytes(), func(m []byte) []byte {
+			sub := siteLink.FindSubmatch(m)
+			return []byte(fmt.Sprintf(`%s="%s"`, sub[1], relativeLink(pg.file, string(sub[2]), files)))
+		})
+		if err := writeSiteFile(dir, pg.file, out); err != nil {
+			return 0, err
+		}
+	}
+
+	// 页面中引用的是带内容哈希的文件名
+	for _, name := range p.r.assets.Names() {
+		data, hashed, _ := p.r.assets.Open(name)
+		if err := writeSiteFile(dir, strings.TrimPrefix(StaticPrefix, "/")+hashed, data); err != nil {
+			return 0, err
+		}
+	}
+	return len(pages), nil
+}
+
+// relativeLink 把服务器上的链接改写为相对 from 的站点文件路径；查询参数在静态站点中没有意义，
+// 不在站点中的页面（运行、测验等需要服务器的功能）保持原样
+func relativeLink(from, link string, files map[string]string) string {
+	target, fragment, _ := strings.Cut(link, "#")
+	target, _, _ = strings.Cut(target, "?")
+	var file string
+	if strings.HasPrefix(target, StaticPrefix) {
+		file = strings.TrimPrefix(target, "/")
+	} else if f, ok := files[target]; ok {
+		file = f
+	} else {
+		return link
+	}
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(file))
+	if err != nil {
+		return link
+	}
+	rel = filepath.ToSlash(rel)
+	if fragment != "" {
+		rel += "#" + fragment
+	}
+	return rel
+}
+
+// writeSiteFile 写入站点中的文件，按需创建目录
+func writeSiteFile(dir, name string, data []byte) error {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
+
+// pageRecorder 收集处理器写出的页面
+type pageRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *pageRecorder) Header() http.Header         { return r.header }
+func (r *pageRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+func (r *pageRecorder) WriteHeader(status int)      { r.status = status }
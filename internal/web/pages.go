@@ -0,0 +1,327 @@
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go-web-api-study/internal/catalog"
+	"go-web-api-study/internal/compare"
+	"go-web-api-study/internal/i18n"
+	"go-web-api-study/internal/symbols"
+	"go-web-api-study/internal/viewer"
+)
+
+// Link 页面上的一个链接，URL 为空时只显示文字
+type Link struct {
+	Label string
+	URL   string
+}
+
+// menuItem 首页的入口，Key 为消息目录中的键
+type menuItem struct {
+	Key  string
+	URL  string
+	Live bool // 需要运行中的服务器，静态站点中不显示
+}
+
+// homeLinks 首页的学习资源入口
+var homeLinks = []menuItem{
+	{Key: "home.link.exercises", URL: "/exercises"},
+	{Key: "home.link.gobase", URL: "/gobase"},
+	{Key: "home.link.progress", URL: "/progress", Live: true},
+	{Key: "home.link.search", URL: "/search", Live: true},
+	{Key: "home.link.quiz", URL: "/quiz", Live: true},
+	{Key: "home.link.review", URL: "/review", Live: true},
+	{Key: "home.link.bench", URL: "/bench", Live: true},
+	{Key: "home.link.api", URL: "/api/hello", Live: true},
+	{Key: "home.link.health", URL: "/health", Live: true},
+}
+
+// homeGoals 首页的学习目标（消息目录中的键）
+var homeGoals = []string{
+	"home.goal.basics",
+	"home.goal.http",
+	"home.goal.rest",
+	"home.goal.database",
+	"home.goal.routing",
+}
+
+// moduleCard 模块卡片的数据
+type moduleCard struct {
+	Index         int
+	Module        catalog.Module
+	Path, Route   string
+	Prerequisites []Link
+	Days          []Link
+}
+
+// moduleLevel 前置关系图中的一列
+type moduleLevel struct {
+	Number  int
+	Modules []catalog.Module
+}
+
+// diffLine 对比页面中的一行，Prefix 为 " "、"-" 或 "+"
+type diffLine struct {
+	Op     compare.Op
+	Prefix string
+	Text   string
+}
+
+// verdictIcons 对比结论的图标
+var verdictIcons = map[compare.Verdict]string{
+	compare.VerdictPass:    "✅",
+	compare.VerdictPartial: "🟡",
+	compare.VerdictFail:    "❌",
+}
+
+// Pages 由学习目录生成的页面：首页、练习列表、模块列表、源码查看、符号定义和输出对比
+type Pages struct {
+	cat *catalog.Catalog
+	idx *symbols.Index
+	r   *Renderer
+}
+
+// NewPages 创建页面集合
+func NewPages(cat *catalog.Catalog, idx *symbols.Index, r *Renderer) *Pages {
+	return &Pages{cat: cat, idx: idx, r: r}
+}
+
+// Home 欢迎页面
+func (p *Pages) Home(w http.ResponseWriter, r *http.Request) {
+	links := homeLinks
+	if p.r.Static {
+		links = nil
+		for _, l := range homeLinks {
+			if !l.Live {
+				links = append(links, l)
+			}
+		}
+	}
+	p.r.Render(w, r, "home", map[string]interface{}{
+		"Links": links,
+		"Goals": homeGoals,
+	})
+}
+
+// Exercises 练习目录页面（由 catalog 扫描 exercises/dayNN 自动生成）
+func (p *Pages) Exercises(w http.ResponseWriter, r *http.Request) {
+	p.r.Render(w, r, "exercises", p.cat.Entries(catalog.KindExercise))
+}
+
+// Gobase Go基础模块页面（由 gobase/manifest.json 模块清单生成）
+func (p *Pages) Gobase(w http.ResponseWriter, r *http.Request) {
+	p.r.Render(w, r, "gobase", p.gobaseData())
+}
+
+// gobaseData 模块页面的数据：模块卡片和按前置关系分层的模块
+func (p *Pages) gobaseData() map[string]interface{} {
+	manifest := p.cat.Manifest()
+	cards := make([]moduleCard, 0, len(manifest.Modules))
+	for i, mod := range manifest.Modules {
+		e, _ := p.cat.ModuleEntry(mod.ID)
+		cards = append(cards, moduleCard{
+			Index:         i + 1,
+			Module:        mod,
+			Path:          e.Path,
+			Route:         e.Route,
+			Prerequisites: p.prerequisites(mod),
+			Days:          p.exerciseDays(mod),
+		})
+	}
+	levels, _ := manifest.Levels() // 启动时已校验过无环
+	graph := make([]moduleLevel, 0, len(levels))
+	for i, level := range levels {
+		graph = append(graph, moduleLevel{Number: i + 1, Modules: level})
+	}
+	return map[string]interface{}{
+		"Modules": cards,
+		"Levels":  graph,
+	}
+}
+
+// sourcePage 源码页面的数据
+type sourcePage struct {
+	Title     string
+	Path      string
+	Group     string
+	Exercise  bool            // 练习文件：显示对比、评分和编辑链接
+	Command   string          // 命令行运行提示，例如 go run gobase/01_variables_and_types.go
+	RunPath   string          // 非空时显示"运行"按钮，提交给 /api/v1/run 的文件路径
+	WatchPath string          // 非空时订阅 /api/v1/watch，该文件变化后自动刷新页面
+	Module    *moduleNav      // gobase 模块的标签和上一个/下一个模块导航
+	Outline   []outlineItem   // Go 源码的符号大纲
+	Lines     []template.HTML // 高亮后的每一行
+}
+
+// outlineItem 符号大纲中的一项
+type outlineItem struct {
+	Mark      string
+	Name      string
+	Signature string
+	Line      int
+}
+
+// symbolMarks 大纲中各类符号的标记
+var symbolMarks = map[symbols.Kind]string{
+	symbols.KindFunc:   "ƒ",
+	symbols.KindMethod: "m",
+	symbols.KindType:   "T",
+}
+
+// Source 显示目录中的文件，Go 源码带符号大纲和定义跳转。
+// 静态站点中不显示运行按钮、文件监听和需要服务器的链接
+func (p *Pages) Source(e catalog.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		content, err := os.ReadFile(filepath.Join(p.cat.Root(), e.Path))
+		if err != nil {
+			http.Error(w, i18n.T(i18n.Of(r), "file.not_found_path", e.Path), http.StatusNotFound)
+			return
+		}
+
+		data := sourcePage{
+			Title:    path.Base(e.Path),
+			Path:     e.Path,
+			Group:    e.Group,
+			Exercise: e.Kind == catalog.KindExercise,
+		}
+		var link viewer.LinkFunc
+		if strings.HasSuffix(e.Path, ".go") {
+			data.Command = "go run " + e.Path
+			p.idx.Refresh()
+			link = p.idx.Linker(e.Path)
+			for _, s := range p.idx.Outline(e.Path) {
+				data.Outline = append(data.Outline, outlineItem{Mark: symbolMarks[s.Kind], Name: s.QualifiedName(), Signature: s.Signature, Line: s.Line})
+			}
+			if !p.r.Static {
+				data.RunPath = e.Path
+			}
+		}
+		if !p.r.Static && (strings.HasPrefix(e.Path, "gobase/") || strings.HasPrefix(e.Path, "exercises/")) {
+			data.WatchPath = e.Path
+		}
+		if p.r.Static && link != nil {
+			// 同名定义的候选列表 /symbols 需要服务器，静态站点中只保留直接跳转到定义的链接
+			linkAll := link
+			link = func(name string, line int) string {
+				if u := linkAll(name, line); !strings.HasPrefix(u, "/symbols") {
+					return u
+				}
+				return ""
+			}
+		}
+		if e.Kind == catalog.KindGobase {
+			data.Module = p.moduleNav(e)
+		}
+		for _, line := range viewer.Highlight(e.Path, content, link) {
+			// viewer 返回的每一行都已转义
+			data.Lines = append(data.Lines, template.HTML(line))
+		}
+		p.r.Render(w, r, "source", data)
+	}
+}
+
+// Symbols /symbols?name=：只有一个定义时直接跳转，否则列出全部候选，没有定义时为 404
+func (p *Pages) Symbols(w http.ResponseWriter, r *http.Request) {
+	p.idx.Refresh()
+	name := r.URL.Query().Get("name")
+	candidates := p.idx.Lookup(name)
+	if len(candidates) == 1 {
+		http.Redirect(w, r, candidates[0].URL(), http.StatusFound)
+		return
+	}
+	status := http.StatusOK
+	if len(candidates) == 0 {
+		status = http.StatusNotFound
+	}
+	p.r.RenderStatus(w, r, status, "symbols", map[string]interface{}{
+		"Name":       name,
+		"Candidates": candidates,
+	})
+}
+
+// Compare 渲染练习与 gobase 参考模块的对比页面
+func (p *Pages) Compare(w http.ResponseWriter, r *http.Request, report *compare.Report) {
+	p.r.Render(w, r, "compare", compareData(report))
+}
+
+// compareData 对比页面的数据
+func compareData(report *compare.Report) interface{} {
+	lines := make([]diffLine, 0, len(report.Diff))
+	for _, line := range report.Diff {
+		prefix := " "
+		switch line.Op {
+		case compare.OpDelete:
+			prefix = "-"
+		case compare.OpInsert:
+			prefix = "+"
+		}
+		lines = append(lines, diffLine{Op: line.Op, Prefix: prefix, Text: line.Text})
+	}
+	return struct {
+		*compare.Report
+		Icon    string
+		Percent float64
+		Lines   []diffLine
+	}{report, verdictIcons[report.Verdict], report.Similarity * 100, lines}
+}
+
+// moduleNav gobase 源码页上的模块导航
+type moduleNav struct {
+	Module        catalog.Module
+	Prerequisites []Link
+	Prev, Next    *Link
+}
+
+// moduleNav 返回 gobase 源码页的标签、前置模块和上一个/下一个模块导航，文件不属于模块时为 nil
+func (p *Pages) moduleNav(e catalog.Entry) *moduleNav {
+	if e.Module == "" {
+		return nil
+	}
+	manifest := p.cat.Manifest()
+	mod, _ := manifest.Module(e.Module)
+	nav := &moduleNav{Module: mod, Prerequisites: p.prerequisites(mod)}
+
+	prev, next := manifest.Neighbors(e.Module)
+	if prev != nil {
+		if pe, ok := p.cat.ModuleEntry(prev.ID); ok {
+			nav.Prev = &Link{Label: prev.Title, URL: pe.Route}
+		}
+	}
+	if next != nil {
+		if ne, ok := p.cat.ModuleEntry(next.ID); ok {
+			nav.Next = &Link{Label: next.Title, URL: ne.Route}
+		}
+	}
+	return nav
+}
+
+// prerequisites 前置模块链接，指向模块页面中的卡片
+func (p *Pages) prerequisites(mod catalog.Module) []Link {
+	manifest := p.cat.Manifest()
+	links := make([]Link, 0, len(mod.Prerequisites))
+	for _, id := range mod.Prerequisites {
+		pre, _ := manifest.Module(id)
+		links = append(links, Link{Label: pre.Title, URL: "/gobase#" + id})
+	}
+	return links
+}
+
+// exerciseDays 模块对应的练习天数，已存在的练习带链接
+func (p *Pages) exerciseDays(mod catalog.Module) []Link {
+	links := make([]Link, 0, len(mod.ExerciseDays))
+	for _, day := range mod.ExerciseDays {
+		group := fmt.Sprintf("day%02d", day)
+		link := Link{Label: fmt.Sprintf("Day %02d", day)}
+		if len(p.cat.Day(group)) > 0 {
+			link.URL = "/exercises/" + group
+		}
+		links = append(links, link)
+	}
+	return links
+}
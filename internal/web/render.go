@@ -0,0 +1,159 @@
+// Package web 使用 html/template 渲染学习服务器的页面：共享布局和局部模板，
+// CSS、脚本、图片等静态资源嵌入二进制，按内容哈希生成 URL
+package web
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"go-web-api-study/docs"
+	"go-web-api-study/internal/handler"
+	"go-web-api-study/internal/i18n"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// 布局和局部模板，每个页面模板都基于它们解析
+const (
+	layoutFile   = "templates/layout.html"
+	partialsFile = "templates/partials.html"
+)
+
+// Renderer 页面渲染器：每种语言、每个页面是布局 + 局部模板 + 页面模板的独立模板集，
+// 模板中的 t 函数按该语言翻译消息目录中的键
+type Renderer struct {
+	// Static 为 true 时渲染静态站点：隐藏语言切换和需要运行中服务器的入口
+	Static bool
+
+	assets *Assets
+	pages  map[i18n.Locale]map[string]*template.Template
+}
+
+// NewRenderer 解析嵌入的模板并收集静态资源（static/ 和 docs 中的图片）
+func NewRenderer() (*Renderer, error) {
+	assets := NewAssets()
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, err
+	}
+	if err := assets.Add("", static); err != nil {
+		return nil, err
+	}
+	if err := assets.Add("img/", docs.Images); err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{
+		assets: assets,
+		pages:  make(map[i18n.Locale]map[string]*template.Template),
+	}
+	files, err := fs.Glob(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	for _, locale := range i18n.Supported {
+		base, err := template.New("layout.html").Funcs(r.funcs(locale)).ParseFS(templateFS, layoutFile, partialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("解析布局模板失败: %w", err)
+		}
+		r.pages[locale] = make(map[string]*template.Template)
+		for _, f := range files {
+			if f == layoutFile || f == partialsFile {
+				continue
+			}
+			t, err := template.Must(base.Clone()).ParseFS(templateFS, f)
+			if err != nil {
+				return nil, fmt.Errorf("解析页面模板 %s 失败: %w", f, err)
+			}
+			r.pages[locale][strings.TrimSuffix(path.Base(f), ".html")] = t
+		}
+	}
+	return r, nil
+}
+
+// funcs 模板函数，t 和 lang 绑定到 locale
+func (r *Renderer) funcs(locale i18n.Locale) template.FuncMap {
+	return template.FuncMap{
+		"asset": r.assets.URL,
+		"add":   func(a, b int) int { return a + b },
+		"join":  strings.Join,
+		// route 把仓库中的文件路径转换为查看页面的路由，如 gobase/05_http_basics.go -> /gobase/05_http_basics
+		"route": func(p string) string { return "/" + strings.TrimSuffix(p, ".go") },
+		"t": func(key string, args ...interface{}) string {
+			return i18n.T(locale, key, args...)
+		},
+		// messages 返回某个前缀下的全部消息，页面脚本中的文字由它传给前端
+		"messages": func(prefix string) map[string]string {
+			return i18n.Messages(locale, prefix)
+		},
+		"lang":    func() i18n.Locale { return locale },
+		"static":  func() bool { return r.Static },
+		"locales": func() []i18n.Locale { return i18n.Supported },
+	}
+}
+
+// Assets 返回静态资源，用于注册 /static/ 处理器
+func (r *Renderer) Assets() *Assets {
+	return r.assets
+}
+
+// Render 按请求的语言使用布局渲染页面，先写入缓冲区，模板执行出错时记录日志并返回
+// "服务器内部错误"，而不是半个页面或模板的错误详情
+func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, page string, data interface{}) {
+	r.RenderStatus(w, req, http.StatusOK, page, data)
+}
+
+// RenderStatus 同 Render，页面以状态码 status 返回，例如没有结果的页面为 404
+func (r *Renderer) RenderStatus(w http.ResponseWriter, req *http.Request, status int, page string, data interface{}) {
+	var buf bytes.Buffer
+	if err := r.execute(&buf, i18n.Of(req), req.URL.Query(), page, data); err != nil {
+		handler.WritePageError(w, req, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// layoutData 布局模板的数据：页面数据和请求的查询参数，切换语言时保留其余参数
+type layoutData struct {
+	Data  interface{}
+	query url.Values
+}
+
+// LangURL 切换到语言 l 的链接，例如 /search?q=chan 切换语言后仍是同一个搜索
+func (d layoutData) LangURL(l i18n.Locale) string {
+	q := make(url.Values, len(d.query)+1)
+	for k, v := range d.query {
+		q[k] = v
+	}
+	q.Set(i18n.QueryParam, string(l))
+	return "?" + q.Encode()
+}
+
+// execute 把页面按语言 locale 渲染到 buf
+func (r *Renderer) execute(buf *bytes.Buffer, locale i18n.Locale, query url.Values, page string, data interface{}) error {
+	pages, ok := r.pages[locale]
+	if !ok {
+		pages = r.pages[i18n.Default]
+	}
+	t, ok := pages[page]
+	if !ok {
+		return fmt.Errorf("页面模板不存在: %s", page)
+	}
+	if err := t.ExecuteTemplate(buf, "layout.html", layoutData{Data: data, query: query}); err != nil {
+		return fmt.Errorf("渲染页面 %s 失败: %w", page, err)
+	}
+	return nil
+}
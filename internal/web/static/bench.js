@@ -0,0 +1,24 @@
+// 基准测试页面脚本：运行一组基准测试后刷新页面；界面文字 messages 由页面给出
+document.querySelectorAll('.run-btn').forEach(function (btn) {
+	btn.addEventListener('click', function () {
+		var errEl = btn.nextElementSibling;
+		var label = btn.textContent;
+		btn.disabled = true;
+		btn.textContent = messages.running;
+		errEl.textContent = '';
+		fetch('/api/v1/bench', {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify({ suite: btn.dataset.suite })
+		}).then(function (resp) {
+			return resp.json().then(function (body) {
+				if (!resp.ok) throw new Error(body.message);
+				location.reload();
+			});
+		}).catch(function (err) {
+			btn.disabled = false;
+			btn.textContent = label;
+			errEl.textContent = '❌ ' + err.message;
+		});
+	});
+});
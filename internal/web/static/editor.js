@@ -0,0 +1,125 @@
+// 编辑页面脚本：行号栏与诊断信息联动，调用 /api/v1/editor 检查、格式化和保存；
+// path、initial 和界面文字 messages 由页面给出
+var src = document.getElementById('src');
+var gutter = document.getElementById('gutter');
+var list = document.getElementById('diags');
+var statusEl = document.getElementById('status');
+var diagnostics = initial;
+
+function render() {
+	var n = src.value.split('\n').length;
+	var byLine = {};
+	diagnostics.forEach(function (d) {
+		if (!byLine[d.line]) byLine[d.line] = d;
+	});
+	var html = '';
+	for (var i = 1; i <= n; i++) {
+		var d = byLine[i];
+		var msgs = diagnostics.filter(function (x) { return x.line === i; }).map(function (x) { return x.message; }).join('\n');
+		html += d ? '<div class="' + d.severity + '" title="' + msgs.replace(/&/g, '&amp;').replace(/"/g, '&quot;') + '">' + i + '</div>' : '<div>' + i + '</div>';
+	}
+	gutter.innerHTML = html;
+	gutter.scrollTop = src.scrollTop;
+
+	list.innerHTML = '';
+	if (diagnostics.length === 0) {
+		var ok = document.createElement('div');
+		ok.className = 'ok';
+		ok.textContent = messages.no_problems;
+		list.appendChild(ok);
+		return;
+	}
+	diagnostics.forEach(function (d) {
+		var div = document.createElement('div');
+		div.className = d.severity;
+		div.textContent = '❌ ' + 'L' + d.line + ':' + d.column + ' [' + d.source + '] ' + d.message;
+		div.addEventListener('click', function () { jump(d.line, d.column); });
+		list.appendChild(div);
+	});
+}
+
+function jump(line, column) {
+	var lines = src.value.split('\n');
+	var pos = 0;
+	for (var i = 0; i < line - 1 && i < lines.length; i++) pos += lines[i].length + 1;
+	pos += Math.max(0, column - 1);
+	src.focus();
+	src.setSelectionRange(pos, pos);
+	src.scrollTop = Math.max(0, (line - 5) * 18);
+}
+
+function call(action) {
+	statusEl.textContent = messages.working;
+	return fetch('/api/v1/editor', {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({ path: path, content: src.value, action: action, format: document.getElementById('fmt-on-save').checked })
+	}).then(function (resp) {
+		return resp.json().then(function (body) {
+			if (!resp.ok) throw new Error(body.message);
+			return body.data;
+		});
+	}).then(function (data) {
+		if (action !== 'check' && data.content !== src.value) {
+			var pos = src.selectionStart, top = src.scrollTop;
+			src.value = data.content;
+			src.setSelectionRange(pos, pos);
+			src.scrollTop = top;
+		}
+		diagnostics = data.diagnostics || [];
+		render();
+		var msg = { check: messages.checked, format: data.formatted ? messages.formatted : messages.not_formatted, save: messages.saved }[action];
+		if (data.backup) msg += messages.backup + data.backup;
+		statusEl.textContent = '✅ ' + msg;
+	}).catch(function (err) {
+		statusEl.textContent = '❌ ' + err.message;
+	});
+}
+
+var timer;
+src.addEventListener('input', function () {
+	render();
+	clearTimeout(timer);
+	timer = setTimeout(function () { call('check'); }, 800);
+});
+src.addEventListener('scroll', function () { gutter.scrollTop = src.scrollTop; });
+src.addEventListener('keydown', function (e) {
+	if (e.key === 'Tab') {
+		e.preventDefault();
+		src.setRangeText('\t', src.selectionStart, src.selectionEnd, 'end');
+	}
+	if ((e.ctrlKey || e.metaKey) && e.key === 's') {
+		e.preventDefault();
+		call('save');
+	}
+});
+document.getElementById('save').addEventListener('click', function () { call('save'); });
+document.getElementById('format').addEventListener('click', function () { call('format'); });
+document.getElementById('check').addEventListener('click', function () { call('check'); });
+document.getElementById('share').addEventListener('click', share);
+render();
+
+// share 把当前内容（包括未保存的修改）保存为代码片段，显示永久链接
+function share() {
+	statusEl.textContent = messages.sharing;
+	fetch('/api/v1/snippets', {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({ content: src.value, source: path, expires_in: document.getElementById('share-ttl').value })
+	}).then(function (resp) {
+		return resp.json().then(function (body) {
+			if (!resp.ok) throw new Error(body.message);
+			return body.data;
+		});
+	}).then(function (data) {
+		var link = location.origin + data.url;
+		statusEl.innerHTML = '';
+		var a = document.createElement('a');
+		a.href = data.url;
+		a.textContent = link;
+		statusEl.append('🔗 ', a);
+		if (navigator.clipboard) navigator.clipboard.writeText(link).then(function () { statusEl.append(messages.copied); });
+	}).catch(function (err) {
+		statusEl.textContent = '❌ ' + err.message;
+	});
+}
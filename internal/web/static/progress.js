@@ -0,0 +1,16 @@
+// 学习进度页面脚本：勾选任务后写回学习计划文档并刷新页面
+document.querySelectorAll('input[data-id]').forEach(function (box) {
+	box.addEventListener('change', function () {
+		fetch('/api/v1/progress', {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify({ id: box.dataset.id, done: box.checked })
+		}).then(function (resp) {
+			if (!resp.ok) throw new Error(messages.save_failed);
+			location.reload();
+		}).catch(function (err) {
+			box.checked = !box.checked;
+			alert(err.message);
+		});
+	});
+});
@@ -0,0 +1,57 @@
+// 测验页面脚本：提交答案并在每道题下显示解析和源码链接；module 和界面文字 messages 由页面给出
+var learnerEl = document.getElementById('learner');
+learnerEl.value = localStorage.getItem('quiz-learner') || '';
+
+function esc(s) {
+	return String(s).replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
+}
+
+function learner() {
+	return learnerEl.value.trim() || learnerEl.placeholder;
+}
+
+function loadHistory() {
+	fetch('/api/v1/quiz/results?learner=' + encodeURIComponent(learner())).then(function (resp) {
+		return resp.json();
+	}).then(function (body) {
+		var el = document.getElementById('history');
+		var stats = (body.data && body.data.modules || []).filter(function (m) { return m.module === module; })[0];
+		el.textContent = stats ? messages.history.replace('{attempts}', stats.attempts).replace('{best}', stats.best) : '';
+	});
+}
+
+learnerEl.addEventListener('change', function () {
+	localStorage.setItem('quiz-learner', learnerEl.value.trim());
+	loadHistory();
+});
+
+document.getElementById('quiz').addEventListener('submit', function (e) {
+	e.preventDefault();
+	var answers = {};
+	new FormData(e.target).forEach(function (value, key) { answers[key] = value; });
+	fetch('/api/v1/quiz', {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({ module: module, learner: learner(), answers: answers })
+	}).then(function (resp) {
+		return resp.json().then(function (body) {
+			if (!resp.ok) throw new Error(body.message);
+			return body.data;
+		});
+	}).then(function (data) {
+		data.items.forEach(function (item) {
+			var el = document.getElementById('q-' + item.question);
+			if (!el) return;
+			el.className = 'question ' + (item.correct ? 'correct' : 'wrong');
+			el.querySelector('.feedback').innerHTML = (item.correct ? esc(messages.correct) : esc(messages.answer) + esc(item.answer)) +
+				'<br>💡 ' + esc(item.explanation) +
+				' <a href="' + esc(item.source.url) + '">📍 ' + esc(item.source.path) + ':' + item.source.line + '</a>';
+		});
+		document.getElementById('result').innerHTML = '<p class="score">' + esc(messages.score.replace('{score}', data.score).replace('{correct}', data.correct).replace('{total}', data.total)) + '</p>';
+		loadHistory();
+	}).catch(function (err) {
+		document.getElementById('result').innerHTML = '<p class="score">❌ ' + esc(err.message) + '</p>';
+	});
+});
+
+loadHistory();
@@ -0,0 +1,22 @@
+// 复习页面脚本：按回忆程度打分后显示下次复习的时间；date 和界面文字 messages 由页面给出
+document.querySelectorAll('.review-card').forEach(function (card) {
+	card.querySelectorAll('button').forEach(function (btn) {
+		btn.addEventListener('click', function () {
+			fetch('/api/v1/review', {
+				method: 'POST',
+				headers: { 'Content-Type': 'application/json' },
+				body: JSON.stringify({ id: card.dataset.id, quality: +btn.dataset.quality, date: date })
+			}).then(function (resp) {
+				return resp.json().then(function (body) {
+					if (!resp.ok) throw new Error(body.message);
+					return body.data;
+				});
+			}).then(function (data) {
+				card.className = 'card review-card done';
+				card.querySelector('.quality').textContent = messages.next.replace('{due}', data.due).replace('{interval}', data.interval);
+			}).catch(function (err) {
+				card.querySelector('.meta').textContent = '❌ ' + err.message;
+			});
+		});
+	});
+});
@@ -0,0 +1,110 @@
+// 源码页面脚本：行号锚点高亮、"运行"按钮和文件变化后自动刷新；界面文字来自页面中的 messages
+(function () {
+	// #L42 和 #L10-L20 锚点高亮；按住 Shift 点击行号可以选择区间
+	var anchor = 0;
+	function mark() {
+		document.querySelectorAll('tr.hl').forEach(function (tr) { tr.classList.remove('hl'); });
+		var m = location.hash.match(/^#L(\d+)(?:-L?(\d+))?$/);
+		if (!m) return;
+		var from = parseInt(m[1], 10), to = m[2] ? parseInt(m[2], 10) : from;
+		if (from > to) { var t = from; from = to; to = t; }
+		for (var i = from; i <= to; i++) {
+			var tr = document.getElementById('L' + i);
+			if (tr) tr.classList.add('hl');
+		}
+		var first = document.getElementById('L' + from);
+		if (first) first.scrollIntoView({ block: 'center' });
+		anchor = from;
+	}
+	document.querySelectorAll('.ln a').forEach(function (a) {
+		a.addEventListener('click', function (e) {
+			var line = parseInt(a.dataset.line, 10);
+			if (e.shiftKey && anchor) {
+				e.preventDefault();
+				location.hash = '#L' + Math.min(anchor, line) + '-L' + Math.max(anchor, line);
+			}
+		});
+	});
+	window.addEventListener('hashchange', mark);
+	mark();
+})();
+
+(function () {
+	// "运行"按钮：POST /api/v1/run 并解析返回的 Server-Sent Events 流
+	var btn = document.getElementById('run-btn');
+	if (!btn) return;
+	var out = document.getElementById('run-output');
+	function append(cls, text) {
+		var span = document.createElement('span');
+		span.className = cls;
+		span.textContent = text;
+		out.appendChild(span);
+		out.scrollTop = out.scrollHeight;
+	}
+	function handle(event, data) {
+		var v = JSON.parse(data);
+		if (event === 'exit') {
+			var msg = v.timed_out ? messages.timeout : (v.exit_code === 0 ? messages.done : messages.exit_code + ' ' + v.exit_code);
+			append('status', '\n' + msg + ' (' + v.duration_ms + 'ms)\n');
+		} else if (event === 'error') {
+			append('stderr', v.message + '\n');
+		} else if (event === 'status') {
+			append('status', '[' + v.data + ']\n');
+		} else {
+			append(event, v.data);
+		}
+	}
+	btn.addEventListener('click', function () {
+		btn.disabled = true;
+		out.hidden = false;
+		out.textContent = '';
+		fetch('/api/v1/run', {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify({ path: btn.dataset.path })
+		}).then(function (resp) {
+			if (!resp.ok) {
+				return resp.json().then(function (body) { append('stderr', body.message + '\n'); });
+			}
+			var reader = resp.body.getReader(), decoder = new TextDecoder(), buf = '';
+			function pump() {
+				return reader.read().then(function (r) {
+					if (r.done) return;
+					buf += decoder.decode(r.value, { stream: true });
+					var parts = buf.split('\n\n');
+					buf = parts.pop();
+					parts.forEach(function (block) {
+						var event = 'message', data = '';
+						block.split('\n').forEach(function (line) {
+							if (line.indexOf('event: ') === 0) event = line.slice(7);
+							if (line.indexOf('data: ') === 0) data += line.slice(6);
+						});
+						if (data) handle(event, data);
+					});
+					return pump();
+				});
+			}
+			return pump();
+		}).catch(function (err) {
+			append('stderr', String(err) + '\n');
+		}).then(function () {
+			btn.disabled = false;
+		});
+	});
+})();
+
+(function () {
+	// 订阅当前文件的变化通知，文件被修改后重新加载页面，被删除时提示
+	var el = document.getElementById('watch');
+	if (!el || !window.EventSource) return;
+	var source = new EventSource('/api/v1/watch?path=' + encodeURIComponent(el.dataset.path));
+	source.addEventListener('change', function (e) {
+		var c = JSON.parse(e.data);
+		if (c.op === 'remove') {
+			document.title = messages.deleted + document.title;
+			return;
+		}
+		source.close();
+		location.reload();
+	});
+})();